@@ -0,0 +1,140 @@
+package uatins
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// BatchItem is a single input for ValidateBatch and ValidateStream.
+type BatchItem struct {
+	TIN string
+	DOB *time.Time
+}
+
+// BatchResult is the outcome of validating the input at Index.
+type BatchResult struct {
+	Index  int
+	Result Result
+	Err    error
+}
+
+// WithWorkers sets the worker pool size for batch validation;
+// n <= 0 uses runtime.GOMAXPROCS(0).
+func WithWorkers(n int) Option {
+	return func(c *Client) {
+		c.workers = n
+	}
+}
+
+// Workers sets the worker pool size for batch validation. Returns a derived client for chaining.
+func (c *Client) Workers(n int) *Client {
+	return c.derive(WithWorkers(n))
+}
+
+// poolSize returns the number of workers to start.
+func (c *Client) poolSize() int {
+	if c.workers > 0 {
+		return c.workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// ValidateBatch validates items across the client's worker pool and
+// returns one BatchResult per item in input order. If ctx is cancelled,
+// it stops early and returns ctx.Err(); results for items that were not
+// processed have a zero Result and Err set to the context error.
+func (c *Client) ValidateBatch(ctx context.Context, items []BatchItem) ([]BatchResult, error) {
+	out := make([]BatchResult, len(items))
+	for i := range out {
+		out[i].Index = i
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := min(c.poolSize(), len(items)); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				out[i].Result, out[i].Err = c.ValidateContext(ctx, items[i].TIN, items[i].DOB)
+			}
+		}()
+	}
+
+	sent := 0
+feed:
+	for ; sent < len(items); sent++ {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- sent:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		for i := sent; i < len(items); i++ {
+			out[i].Err = err
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+// ValidateStream validates items read from in across the client's worker
+// pool. Results are delivered as soon as they are ready, so they may be
+// out of order; Index is the item's position in the input stream. The
+// returned channel is closed once in is drained or ctx is cancelled.
+func (c *Client) ValidateStream(ctx context.Context, in <-chan BatchItem) <-chan BatchResult {
+	type job struct {
+		index int
+		item  BatchItem
+	}
+
+	jobs := make(chan job)
+	out := make(chan BatchResult)
+
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case jobs <- job{index: i, item: item}:
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := c.poolSize(); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				res, err := c.ValidateContext(ctx, j.item.TIN, j.item.DOB)
+				select {
+				case <-ctx.Done():
+					return
+				case out <- BatchResult{Index: j.index, Result: res, Err: err}:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
@@ -0,0 +1,116 @@
+package uatins
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func batchItems(n int) []BatchItem {
+	g := NewGenerator(WithSeed(3))
+	dob := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	items := make([]BatchItem, n)
+	for i := range items {
+		items[i] = BatchItem{TIN: g.MustGenerate(dob, ""), DOB: &dob}
+		if i%10 == 0 {
+			items[i].TIN = "12A"
+		}
+	}
+	return items
+}
+
+func TestValidateBatch_Order(t *testing.T) {
+	items := batchItems(200)
+	client := NewClient(WithStrict(true), WithWorkers(4))
+	got, err := client.ValidateBatch(context.Background(), items)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != len(items) {
+		t.Fatalf("expected %d results, got %d", len(items), len(got))
+	}
+	for i, r := range got {
+		if r.Index != i {
+			t.Fatalf("result %d has index %d", i, r.Index)
+		}
+		if i%10 == 0 {
+			if !errorsIs(r.Err, ErrLength) {
+				t.Fatalf("item %d: expected ErrLength, got %v", i, r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || !r.Result.Valid || r.Result.TIN != items[i].TIN {
+			t.Fatalf("item %d: unexpected result %+v, %v", i, r.Result, r.Err)
+		}
+	}
+}
+
+func TestValidateBatch_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err := NewClient().ValidateBatch(ctx, batchItems(50))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(got) != 50 || !errors.Is(got[49].Err, context.Canceled) {
+		t.Fatalf("expected unprocessed items to carry the context error")
+	}
+}
+
+func TestValidateBatch_CancelledInFlight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewClient(WithRule(NamedRule{
+		Name: "cancel",
+		CheckContext: func(ctx context.Context, _ Result) error {
+			cancel()
+			return ctx.Err()
+		},
+	}))
+	got, err := client.ValidateBatch(ctx, []BatchItem{{TIN: "3036045681"}})
+	if !errors.Is(err, context.Canceled) || !errors.Is(got[0].Err, context.Canceled) {
+		t.Fatalf("expected context.Canceled after the last item was sent, got %v / %v", err, got[0].Err)
+	}
+}
+
+func TestValidateStream(t *testing.T) {
+	items := batchItems(100)
+	in := make(chan BatchItem)
+	go func() {
+		defer close(in)
+		for _, it := range items {
+			in <- it
+		}
+	}()
+
+	var got []BatchResult
+	for r := range NewClient().Workers(3).ValidateStream(context.Background(), in) {
+		got = append(got, r)
+	}
+	if len(got) != len(items) {
+		t.Fatalf("expected %d results, got %d", len(items), len(got))
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].Index < got[j].Index })
+	for i, r := range got {
+		if r.Index != i || (r.Err == nil && r.Result.TIN != items[i].TIN) {
+			t.Fatalf("result %d does not match input: %+v", i, r)
+		}
+	}
+}
+
+func TestValidateStream_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan BatchItem) // never closed
+	out := NewClient().ValidateStream(ctx, in)
+	cancel()
+	select {
+	case _, ok := <-out:
+		for ok {
+			_, ok = <-out
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed after cancellation")
+	}
+}
@@ -0,0 +1,401 @@
+// Package bulk validates TINs stored in CSV or TSV files, such as HR
+// exports, and writes an annotated copy of the file plus a summary of the
+// failures grouped by error code.
+package bulk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// CodeMissingColumn is reported for a row without the TIN or DOB column.
+// A birth date in an unknown layout is reported as uatins.CodeDOBFormat.
+const CodeMissingColumn uatins.ErrorCode = "MISSING_COLUMN"
+
+// ErrMissingColumn is the sentinel for CodeMissingColumn.
+var ErrMissingColumn = errors.New("row has no such column")
+
+func init() {
+	uatins.MustRegisterCode(CodeMissingColumn, ErrMissingColumn)
+}
+
+// Columns appended to every row of the annotated output.
+var annotationHeader = []string{"tin_status", "tin_error_code", "tin_error", "tin_birth_date", "tin_sex"}
+
+// HeaderMode tells the Processor whether the first row is a header.
+type HeaderMode int
+
+const (
+	// HeaderAuto treats the first row as a header when a column is selected
+	// by name, or when its TIN cell contains no digits.
+	HeaderAuto HeaderMode = iota
+	// HeaderPresent always treats the first row as a header.
+	HeaderPresent
+	// HeaderAbsent treats every row as data.
+	HeaderAbsent
+)
+
+// Column selects a field by header name or by zero-based index.
+type Column struct {
+	Name  string
+	Index int
+}
+
+// ByName selects the column whose header is name (case-insensitive).
+func ByName(name string) Column {
+	return Column{Name: name}
+}
+
+// ByIndex selects the column at zero-based index i.
+func ByIndex(i int) Column {
+	return Column{Index: i}
+}
+
+// ParseColumn returns ByIndex for a non-negative integer and ByName
+// otherwise, so "0" selects the first column and "tin" a named one.
+func ParseColumn(s string) Column {
+	if i, err := strconv.Atoi(s); err == nil && i >= 0 {
+		return ByIndex(i)
+	}
+	return ByName(s)
+}
+
+func (c Column) String() string {
+	if c.Name != "" {
+		return strconv.Quote(c.Name)
+	}
+	return "#" + strconv.Itoa(c.Index)
+}
+
+// Processor validates the rows of a delimited file. A Processor is
+// immutable once built and may be shared by goroutines.
+type Processor struct {
+	client  uatins.Validator
+	comma   rune
+	header  HeaderMode
+	tinCol  Column
+	dobCol  *Column
+	layouts []string
+	lang    string
+}
+
+// Option configures a Processor.
+type Option func(*Processor)
+
+// New returns a Processor that validates with client, which may be a
+// *uatins.Client or any decorated Validator, or with a default client if
+// client is nil. By default it reads comma-separated input,
+// detects the header, takes the TIN from the first column, skips the DOB
+// cross-check and parses dates as YYYY-MM-DD or DD.MM.YYYY.
+func New(client uatins.Validator, opts ...Option) *Processor {
+	if client == nil {
+		client = uatins.NewClient()
+	}
+	p := &Processor{
+		client:  client,
+		comma:   ',',
+		tinCol:  ByIndex(0),
+		layouts: []string{"2006-01-02", "02.01.2006"},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithComma sets the field delimiter, e.g. '\t' for TSV or ';'.
+func WithComma(r rune) Option {
+	return func(p *Processor) {
+		p.comma = r
+	}
+}
+
+// WithHeader sets how the first row is treated.
+func WithHeader(mode HeaderMode) Option {
+	return func(p *Processor) {
+		p.header = mode
+	}
+}
+
+// WithTINColumn selects the column holding the TIN.
+func WithTINColumn(col Column) Option {
+	return func(p *Processor) {
+		p.tinCol = col
+	}
+}
+
+// WithDOBColumn selects the column holding the birth date to cross-check
+// against the TIN. Empty cells skip the cross-check for that row.
+func WithDOBColumn(col Column) Option {
+	return func(p *Processor) {
+		p.dobCol = &col
+	}
+}
+
+// WithDateLayouts sets the time.Parse layouts tried, in order, for the
+// DOB column.
+func WithDateLayouts(layouts ...string) Option {
+	return func(p *Processor) {
+		if len(layouts) > 0 {
+			p.layouts = slices.Clone(layouts)
+		}
+	}
+}
+
+// WithLanguage renders the tin_error column and Failure messages in lang
+// (see uatins.Localize). The empty string keeps the client's messages.
+func WithLanguage(lang string) Option {
+	return func(p *Processor) {
+		p.lang = lang
+	}
+}
+
+// Failure describes one row that did not pass validation.
+type Failure struct {
+	Line    int              `json:"line"`
+	TIN     string           `json:"tin"`
+	Code    uatins.ErrorCode `json:"code"`
+	Message string           `json:"message"`
+}
+
+// Summary counts the data rows of a file by outcome.
+type Summary struct {
+	Rows     int                      `json:"rows"`
+	Valid    int                      `json:"valid"`
+	Invalid  int                      `json:"invalid"`
+	ByCode   map[uatins.ErrorCode]int `json:"by_code"`
+	Failures []Failure                `json:"failures"`
+}
+
+// WriteText writes a human-readable report: the totals, the failure
+// counts by code (most frequent first) and one line per failed row.
+func (s *Summary) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rows: %d, valid: %d, invalid: %d\n", s.Rows, s.Valid, s.Invalid)
+	for _, code := range s.codes() {
+		fmt.Fprintf(&b, "  %-20s %d\n", code, s.ByCode[code])
+	}
+	for _, f := range s.Failures {
+		fmt.Fprintf(&b, "line %d: %s: %s: %s\n", f.Line, f.TIN, f.Code, f.Message)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// codes returns the codes of s.ByCode by descending count, then by name.
+func (s *Summary) codes() []uatins.ErrorCode {
+	codes := make([]uatins.ErrorCode, 0, len(s.ByCode))
+	for code := range s.ByCode {
+		codes = append(codes, code)
+	}
+	slices.SortFunc(codes, func(a, b uatins.ErrorCode) int {
+		if d := s.ByCode[b] - s.ByCode[a]; d != 0 {
+			return d
+		}
+		return strings.Compare(string(a), string(b))
+	})
+	return codes
+}
+
+// Process reads delimited rows from in, validates the TIN of each data row
+// and, if out is not nil, writes every row to out with the annotation
+// columns tin_status, tin_error_code, tin_error, tin_birth_date and
+// tin_sex appended. Invalid rows are reported in the Summary; the error is
+// only set for malformed input, a header without the selected columns,
+// write failures or a cancelled ctx, in which case the Summary covers the
+// rows processed so far.
+func (p *Processor) Process(ctx context.Context, in io.Reader, out io.Writer) (*Summary, error) {
+	r := csv.NewReader(in)
+	r.Comma = p.comma
+	r.FieldsPerRecord = -1
+	var w *csv.Writer
+	if out != nil {
+		w = csv.NewWriter(out)
+		w.Comma = p.comma
+	}
+
+	sum := &Summary{ByCode: make(map[uatins.ErrorCode]int)}
+	tinCol, dobCol := p.tinCol, p.dobCol
+	width := 0 // fields in the header, once known
+	first := true
+	for {
+		if err := ctx.Err(); err != nil {
+			return sum, err
+		}
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return sum, fmt.Errorf("bulk: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+
+		if first {
+			first = false
+			if p.isHeader(rec) {
+				width = len(rec)
+				if tinCol, err = resolve(rec, tinCol); err != nil {
+					return sum, err
+				}
+				if dobCol != nil {
+					col, err := resolve(rec, *dobCol)
+					if err != nil {
+						return sum, err
+					}
+					dobCol = &col
+				}
+				if w != nil {
+					if err := w.Write(append(rec, annotationHeader...)); err != nil {
+						return sum, err
+					}
+				}
+				continue
+			}
+			if tinCol.Name != "" || (dobCol != nil && dobCol.Name != "") {
+				return sum, fmt.Errorf("bulk: columns selected by name need a header row")
+			}
+		}
+
+		tin, res, verr := p.validateRow(ctx, rec, tinCol, dobCol)
+		sum.Rows++
+		ann := p.annotate(res, verr)
+		if verr == nil {
+			sum.Valid++
+		} else {
+			sum.Invalid++
+			code := uatins.CodeOf(verr)
+			sum.ByCode[code]++
+			sum.Failures = append(sum.Failures, Failure{Line: line, TIN: tin, Code: code, Message: ann[2]})
+		}
+		if w != nil {
+			// Pad short rows so the annotations line up with their header.
+			for len(rec) < width {
+				rec = append(rec, "")
+			}
+			if err := w.Write(append(rec, ann...)); err != nil {
+				return sum, err
+			}
+		}
+	}
+
+	if w != nil {
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return sum, err
+		}
+	}
+	return sum, nil
+}
+
+// isHeader reports whether the first record is a header row.
+func (p *Processor) isHeader(rec []string) bool {
+	switch p.header {
+	case HeaderPresent:
+		return true
+	case HeaderAbsent:
+		return false
+	}
+	if p.tinCol.Name != "" || (p.dobCol != nil && p.dobCol.Name != "") {
+		return true
+	}
+	if p.tinCol.Index >= len(rec) {
+		return false
+	}
+	return !strings.ContainsAny(rec[p.tinCol.Index], "0123456789")
+}
+
+// resolve turns a named column into an index using the header row.
+func resolve(header []string, col Column) (Column, error) {
+	if col.Name == "" {
+		return col, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), col.Name) {
+			return ByIndex(i), nil
+		}
+	}
+	return col, fmt.Errorf("bulk: header has no column %s", col)
+}
+
+// validateRow validates one data row. A row that passes the client but has
+// a bad checksum or a mismatched birth date (with a lenient client) is
+// still reported as a failure.
+func (p *Processor) validateRow(ctx context.Context, rec []string, tinCol Column, dobCol *Column) (string, uatins.Result, error) {
+	if tinCol.Index >= len(rec) {
+		return "", uatins.Result{}, rowError(CodeMissingColumn, "", fmt.Sprintf("no TIN column %s", tinCol))
+	}
+	tin := strings.TrimSpace(rec[tinCol.Index])
+
+	var dob *time.Time
+	if dobCol != nil {
+		if dobCol.Index >= len(rec) {
+			return tin, uatins.Result{}, rowError(CodeMissingColumn, tin, fmt.Sprintf("no birth date column %s", dobCol))
+		}
+		if s := strings.TrimSpace(rec[dobCol.Index]); s != "" {
+			d, err := p.parseDate(s)
+			if err != nil {
+				return tin, uatins.Result{}, rowError(uatins.CodeDOBFormat, tin, fmt.Sprintf("cannot parse birth date %q", s))
+			}
+			dob = &d
+		}
+	}
+
+	res, err := p.client.ValidateContext(ctx, tin, dob)
+	switch {
+	case err != nil:
+		return tin, res, err
+	case !res.ChecksumOK:
+		return tin, res, uatins.VerifyChecksum(res.TIN)
+	case !res.DOBMatched:
+		d := res.BirthDate
+		return tin, res, &uatins.Error{
+			Code:        uatins.CodeDOBMismatch,
+			TIN:         res.TIN,
+			Msg:         "provided DOB does not match encoded date",
+			DecodedDOB:  &d,
+			ProvidedDOB: dob,
+		}
+	case !res.Valid:
+		return tin, res, &uatins.Error{Code: uatins.CodeUnknown, TIN: res.TIN}
+	}
+	return tin, res, nil
+}
+
+func (p *Processor) parseDate(s string) (time.Time, error) {
+	for _, layout := range p.layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, uatins.ErrDOBFormat
+}
+
+func rowError(code uatins.ErrorCode, tin, msg string) error {
+	return &uatins.Error{Code: code, TIN: tin, Msg: msg}
+}
+
+// annotate returns the annotation columns for one row.
+func (p *Processor) annotate(res uatins.Result, err error) []string {
+	status, code, msg := "valid", "", ""
+	if err != nil {
+		status, code, msg = "invalid", string(uatins.CodeOf(err)), err.Error()
+		if p.lang != "" {
+			msg = uatins.Localize(err, p.lang)
+		}
+	}
+	var birth string
+	if !res.BirthDate.IsZero() {
+		birth = res.BirthDate.Format("2006-01-02")
+	}
+	return []string{status, code, msg, birth, string(res.Sex)}
+}
@@ -0,0 +1,146 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/uatins"
+)
+
+const export = `name;tin;born
+Olena;3036045681;14.02.1983
+Ivan;3036045687;14.02.1983
+Petro;3036045681;1983-02-15
+Maria;12;
+Taras;3036045681;yesterday
+Short
+`
+
+func TestProcess(t *testing.T) {
+	p := New(uatins.NewClient(uatins.WithStrict(true)),
+		WithComma(';'),
+		WithTINColumn(ByName("TIN")),
+		WithDOBColumn(ParseColumn("born")),
+	)
+
+	var out bytes.Buffer
+	sum, err := p.Process(context.Background(), strings.NewReader(export), &out)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if sum.Rows != 6 || sum.Valid != 1 || sum.Invalid != 5 {
+		t.Fatalf("unexpected totals: %+v", sum)
+	}
+	want := map[uatins.ErrorCode]int{
+		uatins.CodeChecksum:    1,
+		uatins.CodeDOBMismatch: 1,
+		uatins.CodeLength:      1,
+		uatins.CodeDOBFormat:   1,
+		CodeMissingColumn:      1,
+	}
+	for code, n := range want {
+		if sum.ByCode[code] != n {
+			t.Errorf("ByCode[%s] = %d, want %d", code, sum.ByCode[code], n)
+		}
+	}
+	if f := sum.Failures[0]; f.Line != 3 || f.TIN != "3036045687" || f.Code != uatins.CodeChecksum {
+		t.Errorf("unexpected first failure: %+v", f)
+	}
+
+	r := csv.NewReader(&out)
+	r.Comma = ';'
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil || len(rows) != 7 {
+		t.Fatalf("annotated output: %d rows, %v", len(rows), err)
+	}
+	if got := strings.Join(rows[0], ","); got != "name,tin,born,tin_status,tin_error_code,tin_error,tin_birth_date,tin_sex" {
+		t.Errorf("unexpected header %q", got)
+	}
+	if got := strings.Join(rows[1][3:], ","); got != "valid,,,1983-02-14,female" {
+		t.Errorf("unexpected annotation %q", got)
+	}
+	if rows[2][3] != "invalid" || rows[2][4] != "CHECKSUM" {
+		t.Errorf("unexpected annotation %q", rows[2])
+	}
+	if short := rows[6]; len(short) != len(rows[0]) || short[0] != "Short" || short[3] != "invalid" || short[4] != string(CodeMissingColumn) {
+		t.Errorf("short row not padded to the header: %q", short)
+	}
+}
+
+func TestProcess_LenientDOBMismatch(t *testing.T) {
+	p := New(nil, WithDOBColumn(ByIndex(1)), WithHeader(HeaderAbsent))
+	sum, err := p.Process(context.Background(), strings.NewReader("3036045681,1983-02-15\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.Invalid != 1 || sum.ByCode[uatins.CodeDOBMismatch] != 1 {
+		t.Fatalf("a mismatched DOB must be reported even by a lenient client: %+v", sum)
+	}
+	if msg := sum.Failures[0].Message; msg != "provided DOB does not match encoded date" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestProcess_HeaderDetection(t *testing.T) {
+	tsv := "3036045681\t14.02.1983\n3036045687\t\n"
+	p := New(nil, WithComma('\t'), WithDOBColumn(ByIndex(1)))
+	var out bytes.Buffer
+	sum, err := p.Process(context.Background(), strings.NewReader(tsv), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.Rows != 2 || sum.Valid != 1 {
+		t.Fatalf("first row must be data: %+v", sum)
+	}
+	if !strings.HasPrefix(out.String(), "3036045681\t14.02.1983\tvalid\t") {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	sum, err = New(nil).Process(context.Background(), strings.NewReader("ІПН\n3036045681\n"), nil)
+	if err != nil || sum.Rows != 1 {
+		t.Fatalf("header row must be skipped: %+v, %v", sum, err)
+	}
+}
+
+func TestProcess_Errors(t *testing.T) {
+	ctx := context.Background()
+	if _, err := New(nil, WithTINColumn(ByName("inn"))).Process(ctx, strings.NewReader("tin\n3036045681\n"), nil); err == nil {
+		t.Error("expected an error for an unknown column name")
+	}
+	p := New(nil, WithTINColumn(ByName("tin")), WithHeader(HeaderAbsent))
+	if _, err := p.Process(ctx, strings.NewReader("3036045681\n"), nil); err == nil {
+		t.Error("expected an error for a named column without a header")
+	}
+	if _, err := New(nil).Process(ctx, strings.NewReader("\"3036045681\n"), nil); err == nil {
+		t.Error("expected a CSV parse error")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := New(nil).Process(cancelled, strings.NewReader("3036045681\n"), nil); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSummary_WriteText(t *testing.T) {
+	p := New(nil, WithLanguage(uatins.LangUkrainian))
+	sum, err := p.Process(context.Background(), strings.NewReader("3036045681\n12\n13\n3036045687\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := sum.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := "rows: 4, valid: 1, invalid: 3\n" +
+		"  LENGTH               2\n" +
+		"  CHECKSUM             1\n" +
+		"line 2: 12: LENGTH: РНОКПП має містити 10 цифр\n"
+	if !strings.HasPrefix(b.String(), want) {
+		t.Fatalf("unexpected report:\n%s", b.String())
+	}
+}
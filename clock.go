@@ -0,0 +1,41 @@
+package uatins
+
+import "time"
+
+// Clock reports the current time. Client.Validate consults it on every
+// call to judge whether an encoded birth date is plausible.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock reads the wall clock.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock returns the real-time Clock used by default.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// FixedClock is a Clock that always reports the same instant (useful for tests).
+type FixedClock time.Time
+
+// Now returns the fixed instant in UTC.
+func (f FixedClock) Now() time.Time {
+	return time.Time(f).In(time.UTC)
+}
+
+// WithClock sets the time source used for plausibility checks; nil is ignored.
+func WithClock(clk Clock) Option {
+	return func(c *Client) {
+		if clk != nil {
+			c.clock = clk
+		}
+	}
+}
+
+// Clock sets the time source used for plausibility checks. Returns a derived client for chaining.
+func (c *Client) Clock(clk Clock) *Client {
+	return c.derive(WithClock(clk))
+}
@@ -0,0 +1,45 @@
+package uatins
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stepClock is a Clock whose time can be advanced between calls.
+type stepClock struct {
+	unix atomic.Int64
+}
+
+func (s *stepClock) Now() time.Time { return time.Unix(s.unix.Load(), 0).UTC() }
+
+func TestClock_ConsultedPerCall(t *testing.T) {
+	clk := &stepClock{}
+	clk.unix.Store(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix())
+	client := NewClient(WithClock(clk))
+
+	dob := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tin := NewGenerator(WithSeed(1)).MustGenerate(dob, Male)
+
+	if _, err := client.Validate(tin, nil); !errorsIs(err, ErrBirthOutOfRange) {
+		t.Fatalf("expected ErrBirthOutOfRange before birth, got %v", err)
+	}
+
+	clk.unix.Store(time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC).Unix())
+	res, err := client.Validate(tin, nil)
+	if err != nil || !res.Valid {
+		t.Fatalf("expected valid after the clock advanced, got %+v, %v", res, err)
+	}
+}
+
+func TestFixedClock(t *testing.T) {
+	at := time.Date(2000, 1, 1, 12, 0, 0, 0, time.FixedZone("EET", 2*3600))
+	if got := FixedClock(at).Now(); !got.Equal(at) || got.Location() != time.UTC {
+		t.Fatalf("unexpected FixedClock.Now: %s", got)
+	}
+
+	client := NewClient().Clock(FixedClock(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if _, err := client.Validate("3036045681", nil); !errorsIs(err, ErrBirthOutOfRange) {
+		t.Fatalf("expected ErrBirthOutOfRange for a 1983 birth date in 1980, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+// Command uatins-server runs the httpapi validation service standalone.
+//
+// Usage:
+//
+//	uatins-server [-addr :8080] [-strict] [-max-age 130] [-lang uk]
+//
+// The OpenAPI document is served at /openapi.json. The server shuts down
+// gracefully on SIGINT or SIGTERM.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+	"github.com/stremovskyy/uatins/httpapi"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	strict := flag.Bool("strict", false, "treat a DOB mismatch as a validation error")
+	maxAge := flag.Int("max-age", 130, "maximum plausible age in years; 0 disables the cap")
+	allErrors := flag.Bool("all-errors", false, "report every failed rule instead of the first")
+	lang := flag.String("lang", "", "language of error messages (en, uk)")
+	workers := flag.Int("workers", 0, "batch worker pool size (0: GOMAXPROCS)")
+	maxBatch := flag.Int("max-batch", 1000, "maximum items per batch request")
+	timeout := flag.Duration("timeout", 10*time.Second, "per-request timeout")
+	flag.Parse()
+
+	client := uatins.NewClient(
+		uatins.WithStrict(*strict),
+		uatins.WithMaxAge(*maxAge),
+		uatins.WithAllErrors(*allErrors),
+		uatins.WithLanguage(*lang),
+		uatins.WithWorkers(*workers),
+	)
+	handler := httpapi.New(client, httpapi.WithMaxBatch(*maxBatch))
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           http.TimeoutHandler(handler, *timeout, `{"code":"UNAVAILABLE","message":"request timed out"}`),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      *timeout + time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("uatins-server listening on %s", *addr)
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	case <-ctx.Done():
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdown); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stremovskyy/uatins"
+	"github.com/stremovskyy/uatins/bulk"
+)
+
+func runBulk(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("bulk", stderr)
+	delim := fs.String("delimiter", ",", `field delimiter; "tab" for TSV`)
+	tsv := fs.Bool("tsv", false, "shorthand for --delimiter tab")
+	header := fs.String("header", "auto", "first row is a header: auto, yes or no")
+	tinCol := fs.String("tin-col", "0", "TIN column: header name or zero-based index")
+	dobCol := fs.String("dob-col", "", "birth date column to cross-check: header name or zero-based index")
+	dateFormat := fs.String("date-format", "", "comma-separated Go time layouts for the birth date (default 2006-01-02,02.01.2006)")
+	strict := fs.Bool("strict", false, "treat a DOB mismatch as a validation error")
+	maxAge := fs.Int("max-age", 130, "maximum plausible age in years; 0 disables the cap")
+	lang := fs.String("lang", "", "language of error messages (en, uk)")
+	outPath := fs.String("out", "", "annotated output file (default: standard output)")
+	reportPath := fs.String("report", "", "failure summary file (default: standard error)")
+	reportFormat := fs.String("report-format", "text", "summary format: text or json")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 1 {
+		fmt.Fprintln(stderr, "usage: uatins bulk [flags] [FILE]")
+		return exitUsage
+	}
+
+	comma, err := parseDelimiter(*delim, *tsv)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	opts := []bulk.Option{
+		bulk.WithComma(comma),
+		bulk.WithTINColumn(bulk.ParseColumn(*tinCol)),
+		bulk.WithLanguage(*lang),
+	}
+	switch *header {
+	case "auto":
+	case "yes":
+		opts = append(opts, bulk.WithHeader(bulk.HeaderPresent))
+	case "no":
+		opts = append(opts, bulk.WithHeader(bulk.HeaderAbsent))
+	default:
+		return fail(stderr, fmt.Errorf("unknown header mode %q (want auto, yes or no)", *header))
+	}
+	if *dobCol != "" {
+		opts = append(opts, bulk.WithDOBColumn(bulk.ParseColumn(*dobCol)))
+	}
+	if *dateFormat != "" {
+		opts = append(opts, bulk.WithDateLayouts(strings.Split(*dateFormat, ",")...))
+	}
+	if *reportFormat != "text" && *reportFormat != "json" {
+		return fail(stderr, fmt.Errorf("unknown report format %q (want text or json)", *reportFormat))
+	}
+
+	in := stdin
+	if fs.NArg() == 1 && fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return fail(stderr, err)
+		}
+		defer f.Close()
+		in = f
+	}
+	out := stdout
+	var outFile *os.File
+	if *outPath != "" {
+		if outFile, err = os.Create(*outPath); err != nil {
+			return fail(stderr, err)
+		}
+		defer outFile.Close()
+		out = outFile
+	}
+
+	client := uatins.NewClient(
+		uatins.WithStrict(*strict),
+		uatins.WithMaxAge(*maxAge),
+		uatins.WithLanguage(*lang),
+	)
+	sum, err := bulk.New(client, opts...).Process(context.Background(), in, out)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	if outFile != nil {
+		if err := outFile.Close(); err != nil {
+			return fail(stderr, err)
+		}
+	}
+
+	report := stderr
+	if *reportPath != "" {
+		f, err := os.Create(*reportPath)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		defer f.Close()
+		report = f
+	}
+	if *reportFormat == "json" {
+		err = json.NewEncoder(report).Encode(sum)
+	} else {
+		err = sum.WriteText(report)
+	}
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if sum.Invalid > 0 {
+		return exitInvalid
+	}
+	return exitOK
+}
+
+// parseDelimiter turns the --delimiter flag into a single rune.
+func parseDelimiter(s string, tsv bool) (rune, error) {
+	if tsv {
+		return '\t', nil
+	}
+	switch s {
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) {
+		return 0, fmt.Errorf("delimiter must be a single character, got %q", s)
+	}
+	return r, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// decodeOutput is the JSON form of one decoded input.
+type decodeOutput struct {
+	Input      string     `json:"input"`
+	TIN        string     `json:"tin"`
+	BirthDate  string     `json:"birth_date,omitempty"`
+	Sex        uatins.Sex `json:"sex,omitempty"`
+	Age        *int       `json:"age,omitempty"`
+	ChecksumOK bool       `json:"checksum_ok"`
+	Error      error      `json:"error,omitempty"`
+}
+
+var decodeHeader = []string{"input", "tin", "birth_date", "sex", "age", "checksum_ok", "error_code", "error"}
+
+func runDecode(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("decode", stderr)
+	format := fs.String("format", "text", "output format: text, json or csv")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	tins, err := inputs(fs.Args(), stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	p, err := newPrinter(*format, stdout, decodeHeader, decodeText)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	// Decoding should report what the digits say even for an implausible
+	// birth date, so the plausibility rule is disabled.
+	client := uatins.NewClient(uatins.WithoutRules(uatins.RulePlausible))
+	now := time.Now()
+
+	code := exitOK
+	for _, in := range tins {
+		res, err := client.Validate(in, nil)
+		out := decodeOutput{Input: in, TIN: res.TIN, ChecksumOK: res.ChecksumOK, Error: err}
+		if err != nil {
+			code = exitInvalid
+		} else {
+			age := ageAt(res.BirthDate, now)
+			out.BirthDate = res.BirthDate.Format("2006-01-02")
+			out.Sex = res.Sex
+			out.Age = &age
+		}
+		if err := p.print(decodeRecord(out)); err != nil {
+			return fail(stderr, err)
+		}
+	}
+	if err := p.flush(); err != nil {
+		return fail(stderr, err)
+	}
+	return code
+}
+
+// ageAt returns the number of full years between birth and now.
+func ageAt(birth, now time.Time) int {
+	by, bm, bd := birth.Date()
+	ny, nm, nd := now.Date()
+	age := ny - by
+	if nm < bm || (nm == bm && nd < bd) {
+		age--
+	}
+	return age
+}
+
+func decodeRecord(out decodeOutput) record {
+	var age, code, msg string
+	if out.Age != nil {
+		age = strconv.Itoa(*out.Age)
+	}
+	if out.Error != nil {
+		code, msg = string(uatins.CodeOf(out.Error)), out.Error.Error()
+	}
+	return record{
+		json: out,
+		cols: []string{
+			out.Input, out.TIN, out.BirthDate, string(out.Sex), age,
+			strconv.FormatBool(out.ChecksumOK), code, msg,
+		},
+	}
+}
+
+func decodeText(r record) string {
+	in, birth, sex, age, checksum, code, msg := r.cols[0], r.cols[2], r.cols[3], r.cols[4], r.cols[5], r.cols[6], r.cols[7]
+	if code != "" {
+		return fmt.Sprintf("%s\terror\t%s\t%s", in, code, msg)
+	}
+	s := fmt.Sprintf("%s\t%s\t%s\tage %s", in, birth, sex, age)
+	if checksum != "true" {
+		s += "\t(checksum mismatch)"
+	}
+	return s
+}
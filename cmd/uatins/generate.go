@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// generateOutput is the JSON form of one generated TIN.
+type generateOutput struct {
+	TIN       string     `json:"tin"`
+	BirthDate string     `json:"birth_date"`
+	Sex       uatins.Sex `json:"sex"`
+}
+
+var generateHeader = []string{"tin", "birth_date", "sex"}
+
+func runGenerate(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("generate", stderr)
+	dobFlag := fs.String("dob", "", "birth date, YYYY-MM-DD (default: random between 1950 and 2005)")
+	sexFlag := fs.String("sex", "", "male or female (default: random)")
+	count := fs.Int("count", 1, "number of TINs to generate")
+	seed := fs.Uint64("seed", 0, "seed for reproducible output (0: random)")
+	serialMin := fs.Int("serial-min", 0, "smallest 4-digit serial")
+	serialMax := fs.Int("serial-max", 9999, "largest 4-digit serial")
+	format := fs.String("format", "text", "output format: text, json or csv")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	dob, err := parseDate(*dobFlag)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	var sex uatins.Sex
+	if err := sex.UnmarshalText([]byte(*sexFlag)); err != nil {
+		return fail(stderr, err)
+	}
+	if *count < 1 {
+		return fail(stderr, errors.New("count must be positive"))
+	}
+	p, err := newPrinter(*format, stdout, generateHeader, func(r record) string { return r.cols[0] })
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	// Random birth dates come from the same seed so output is reproducible.
+	if *seed == 0 {
+		*seed = rand.Uint64()
+	}
+	g := uatins.NewGenerator(uatins.WithSeed(*seed), uatins.WithSerialRange(*serialMin, *serialMax))
+	dates := rand.New(rand.NewPCG(*seed, ^*seed))
+	from := uatins.DateToDays(time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC))
+	to := uatins.DateToDays(time.Date(2005, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	for i := 0; i < *count; i++ {
+		birth := dob
+		if birth == nil {
+			d := uatins.DaysToDate(from + dates.IntN(to-from+1))
+			birth = &d
+		}
+		tin, err := g.Generate(*birth, sex)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		t := uatins.TIN(tin)
+		out := generateOutput{TIN: tin, BirthDate: birth.Format("2006-01-02"), Sex: t.Sex()}
+		if err := p.print(record{json: out, cols: []string{out.TIN, out.BirthDate, string(out.Sex)}}); err != nil {
+			return fail(stderr, err)
+		}
+	}
+	if err := p.flush(); err != nil {
+		return fail(stderr, err)
+	}
+	return exitOK
+}
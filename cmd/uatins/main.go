@@ -0,0 +1,195 @@
+// Command uatins validates, decodes, generates and corrects Ukrainian
+// taxpayer numbers (RNOKPP) from the command line.
+//
+// Usage:
+//
+//	uatins validate [flags] [TIN...]
+//	uatins decode   [flags] [TIN...]
+//	uatins generate [flags]
+//	uatins suggest  [flags] TIN
+//	uatins bulk     [flags] [FILE]
+//
+// TINs are read from the arguments or, if none are given, one per line
+// from standard input. Every subcommand except bulk accepts
+// --format text|json|csv; json writes one object per line.
+//
+// bulk validates a CSV or TSV file, writes it back with annotation columns
+// appended and prints a summary of the failures by error code.
+//
+// Exit status is 0 when every input is valid, 1 when at least one is not
+// and 2 on usage or I/O errors.
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Exit codes.
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+const usage = `usage: uatins <command> [flags] [TIN...]
+
+commands:
+  validate   check TINs (optionally against a birth date)
+  decode     print the birth date, sex and age encoded in TINs
+  generate   produce synthetic TINs with a valid checksum
+  suggest    propose corrections for a mistyped TIN
+  bulk       validate the rows of a CSV or TSV file
+
+Run "uatins <command> -h" for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	var cmd func([]string, io.Reader, io.Writer, io.Writer) int
+	switch args[0] {
+	case "validate":
+		cmd = runValidate
+	case "decode":
+		cmd = runDecode
+	case "generate":
+		cmd = runGenerate
+	case "suggest":
+		cmd = runSuggest
+	case "bulk":
+		cmd = runBulk
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "uatins: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return cmd(args[1:], stdin, stdout, stderr)
+}
+
+// newFlagSet returns a flag set that reports errors to stderr.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("uatins "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// inputs returns the positional arguments, or the non-blank lines of stdin.
+func inputs(args []string, stdin io.Reader) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	var out []string
+	sc := bufio.NewScanner(stdin)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out, sc.Err()
+}
+
+// parseDate parses an optional YYYY-MM-DD flag value.
+func parseDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, want YYYY-MM-DD", s)
+	}
+	return &t, nil
+}
+
+// printer writes records in the selected output format.
+type printer struct {
+	format string
+	out    io.Writer
+	csv    *csv.Writer
+	header []string
+	text   func(record) string
+}
+
+// record is one output row: the JSON value and its flat CSV columns.
+type record struct {
+	json any
+	cols []string
+}
+
+func newPrinter(format string, out io.Writer, header []string, text func(record) string) (*printer, error) {
+	p := &printer{format: format, out: out, header: header, text: text}
+	switch format {
+	case "text", "json":
+	case "csv":
+		p.csv = csv.NewWriter(out)
+		if err := p.csv.Write(header); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q (want text, json or csv)", format)
+	}
+	return p, nil
+}
+
+func (p *printer) print(r record) error {
+	switch p.format {
+	case "json":
+		b, err := json.Marshal(r.json)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.out, "%s\n", b)
+		return err
+	case "csv":
+		return p.csv.Write(r.cols)
+	default:
+		_, err := fmt.Fprintln(p.out, p.text(r))
+		return err
+	}
+}
+
+func (p *printer) flush() error {
+	if p.csv != nil {
+		p.csv.Flush()
+		return p.csv.Error()
+	}
+	return nil
+}
+
+// parseFlags parses args into fs. It returns false with the exit code to
+// use when parsing stops; the flag package has already printed the reason.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	err := fs.Parse(args)
+	switch {
+	case err == nil:
+		return exitOK, true
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK, false
+	default:
+		return exitUsage, false
+	}
+}
+
+// fail reports err and returns the usage exit code.
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintln(stderr, "uatins:", err)
+	return exitUsage
+}
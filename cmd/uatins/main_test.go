@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runCmd(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	if code, _, stderr := runCmd(t, ""); code != exitUsage || !strings.Contains(stderr, "usage:") {
+		t.Fatalf("no args: code %d, stderr %q", code, stderr)
+	}
+	if code, _, _ := runCmd(t, "", "frobnicate"); code != exitUsage {
+		t.Fatalf("unknown command: code %d", code)
+	}
+	if code, _, _ := runCmd(t, "", "validate", "--format", "xml", "3036045681"); code != exitUsage {
+		t.Fatalf("unknown format: code %d", code)
+	}
+	if code, _, _ := runCmd(t, "", "validate", "--nope"); code != exitUsage {
+		t.Fatalf("unknown flag: code %d", code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	code, out, _ := runCmd(t, "", "validate", "3036045681")
+	if code != exitOK || out != "3036045681\tvalid\t1983-02-14\tfemale\n" {
+		t.Fatalf("code %d, out %q", code, out)
+	}
+
+	code, out, _ = runCmd(t, "3036045681\n\n3036045687\n", "validate")
+	if code != exitInvalid {
+		t.Fatalf("expected exit %d, got %d", exitInvalid, code)
+	}
+	if !strings.Contains(out, "3036045687\tinvalid\tCHECKSUM") {
+		t.Fatalf("unexpected output %q", out)
+	}
+
+	code, _, _ = runCmd(t, "", "validate", "--strict", "--dob", "1983-02-15", "3036045681")
+	if code != exitInvalid {
+		t.Fatalf("strict DOB mismatch: code %d", code)
+	}
+	if code, _, _ := runCmd(t, "", "validate", "--dob", "15.02.1983", "3036045681"); code != exitUsage {
+		t.Fatalf("bad date: code %d", code)
+	}
+}
+
+func TestValidate_JSON(t *testing.T) {
+	_, out, _ := runCmd(t, "", "validate", "--format", "json", "3036045681", "12")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", out)
+	}
+
+	var rec struct {
+		Input  string `json:"input"`
+		Result struct {
+			Valid bool `json:"valid"`
+		} `json:"result"`
+		Error *struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil || !rec.Result.Valid || rec.Error != nil {
+		t.Fatalf("line 1: %+v, %v", rec, err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil || rec.Error == nil || rec.Error.Code != "LENGTH" {
+		t.Fatalf("line 2: %+v, %v", rec, err)
+	}
+}
+
+func TestValidate_CSV(t *testing.T) {
+	_, out, _ := runCmd(t, "", "validate", "--format", "csv", "--lang", "uk", "3036045687")
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("rows %v, err %v", rows, err)
+	}
+	if rows[0][0] != "input" || rows[1][7] != "CHECKSUM" || !strings.Contains(rows[1][8], "контрольна цифра") {
+		t.Fatalf("unexpected rows %q", rows)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	code, out, _ := runCmd(t, "", "decode", "--format", "json", "3036045687")
+	if code != exitOK {
+		t.Fatalf("checksum mismatch should still decode, code %d", code)
+	}
+	var rec decodeOutput
+	if err := json.Unmarshal([]byte(out), &struct {
+		*decodeOutput
+		Error json.RawMessage `json:"error"`
+	}{decodeOutput: &rec}); err != nil {
+		t.Fatal(err)
+	}
+	if rec.BirthDate != "1983-02-14" || rec.Sex != "female" || rec.Age == nil || rec.ChecksumOK {
+		t.Fatalf("unexpected decode %+v", rec)
+	}
+
+	if code, _, _ := runCmd(t, "", "decode", "1111111111"); code != exitInvalid {
+		t.Fatalf("all-same: code %d", code)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	args := []string{"generate", "--count", "5", "--seed", "42", "--dob", "1990-06-01", "--sex", "male"}
+	code, out, _ := runCmd(t, "", args...)
+	if code != exitOK {
+		t.Fatalf("code %d", code)
+	}
+	tins := strings.Fields(out)
+	if len(tins) != 5 {
+		t.Fatalf("expected 5 TINs, got %q", out)
+	}
+	for _, tin := range tins {
+		if c, line, _ := runCmd(t, "", "validate", "--dob", "1990-06-01", "--strict", tin); c != exitOK || !strings.Contains(line, "male") {
+			t.Fatalf("generated %s does not validate: %q", tin, line)
+		}
+	}
+	if _, again, _ := runCmd(t, "", args...); again != out {
+		t.Fatalf("same seed produced different output:\n%s\n%s", out, again)
+	}
+
+	if code, _, _ := runCmd(t, "", "generate", "--sex", "other"); code != exitUsage {
+		t.Fatalf("bad sex: code %d", code)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	code, out, _ := runCmd(t, "", "suggest", "--dob", "1983-02-14", "--sex", "female", "3036045618")
+	if code != exitOK || !strings.HasPrefix(out, "3036045681\ttransposition at 8") {
+		t.Fatalf("code %d, out %q", code, out)
+	}
+	if code, _, _ := runCmd(t, "", "suggest", "3036045681"); code != exitOK {
+		t.Fatalf("valid input: code %d", code)
+	}
+	if code, _, _ := runCmd(t, "", "suggest", "12"); code != exitInvalid {
+		t.Fatalf("malformed input: code %d", code)
+	}
+	if code, _, _ := runCmd(t, "", "suggest"); code != exitUsage {
+		t.Fatalf("missing TIN: code %d", code)
+	}
+}
+
+func TestBulk(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "hr.tsv")
+	data := "name\ttin\tborn\nOlena\t3036045681\t14.02.1983\nIvan\t3036045687\t14.02.1983\n"
+	if err := os.WriteFile(in, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "hr.checked.tsv")
+
+	code, _, stderr := runCmd(t, "", "bulk", "--tsv", "--tin-col", "tin", "--dob-col", "born", "--out", out, in)
+	if code != exitInvalid {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	if !strings.HasPrefix(stderr, "rows: 2, valid: 1, invalid: 1\n  CHECKSUM") {
+		t.Fatalf("unexpected report %q", stderr)
+	}
+	annotated, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(annotated), "Olena\t3036045681\t14.02.1983\tvalid\t\t\t1983-02-14\tfemale\n") {
+		t.Fatalf("unexpected annotated file:\n%s", annotated)
+	}
+
+	code, stdout, stderr := runCmd(t, "3036045681\n", "bulk", "--report-format", "json")
+	if code != exitOK || stdout != "3036045681,valid,,,1983-02-14,female\n" || !strings.Contains(stderr, `"valid":1`) {
+		t.Fatalf("stdin mode: code %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	if code, _, _ := runCmd(t, "", "bulk", "--delimiter", ";;"); code != exitUsage {
+		t.Fatalf("bad delimiter: code %d", code)
+	}
+}
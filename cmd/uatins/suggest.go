@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// suggestOutput is the JSON form of one suggestion.
+type suggestOutput struct {
+	TIN       string          `json:"tin"`
+	Kind      uatins.EditKind `json:"kind"`
+	Position  int             `json:"position"`
+	BirthDate string          `json:"birth_date"`
+	Sex       uatins.Sex      `json:"sex"`
+	Score     float64         `json:"score"`
+}
+
+var suggestHeader = []string{"tin", "kind", "position", "birth_date", "sex", "score"}
+
+func runSuggest(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("suggest", stderr)
+	dobFlag := fs.String("dob", "", "keep only candidates with this birth date, YYYY-MM-DD")
+	sexFlag := fs.String("sex", "", "keep only candidates of this sex: male or female")
+	format := fs.String("format", "text", "output format: text, json or csv")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: uatins suggest [flags] TIN")
+		return exitUsage
+	}
+
+	var opts []uatins.SuggestOption
+	dob, err := parseDate(*dobFlag)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	if dob != nil {
+		opts = append(opts, uatins.SuggestDOB(*dob))
+	}
+	var sex uatins.Sex
+	if err := sex.UnmarshalText([]byte(*sexFlag)); err != nil {
+		return fail(stderr, err)
+	}
+	if sex != "" {
+		opts = append(opts, uatins.SuggestSex(sex))
+	}
+	p, err := newPrinter(*format, stdout, suggestHeader, suggestText)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	in := fs.Arg(0)
+	suggestions, err := uatins.NewClient().Suggest(in, opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "uatins: %s: %v\n", in, err)
+		return exitInvalid
+	}
+	if _, err := uatins.Parse(in); err == nil {
+		fmt.Fprintf(stderr, "uatins: %s is already valid\n", in)
+		return exitOK
+	}
+	for _, s := range suggestions {
+		out := suggestOutput{
+			TIN:       s.TIN,
+			Kind:      s.Kind,
+			Position:  s.Position,
+			BirthDate: s.BirthDate.Format("2006-01-02"),
+			Sex:       s.Sex,
+			Score:     s.Score,
+		}
+		r := record{json: out, cols: []string{
+			out.TIN, string(out.Kind), strconv.Itoa(out.Position), out.BirthDate, string(out.Sex),
+			strconv.FormatFloat(out.Score, 'f', -1, 64),
+		}}
+		if err := p.print(r); err != nil {
+			return fail(stderr, err)
+		}
+	}
+	if err := p.flush(); err != nil {
+		return fail(stderr, err)
+	}
+	if len(suggestions) == 0 {
+		return exitInvalid
+	}
+	return exitOK
+}
+
+func suggestText(r record) string {
+	return fmt.Sprintf("%s\t%s at %s\t%s\t%s\t%s", r.cols[0], r.cols[1], r.cols[2], r.cols[3], r.cols[4], r.cols[5])
+}
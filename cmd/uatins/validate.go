@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// validateOutput is the JSON form of one validated input.
+type validateOutput struct {
+	Input  string        `json:"input"`
+	Result uatins.Result `json:"result"`
+	Error  error         `json:"error,omitempty"`
+}
+
+var validateHeader = []string{"input", "tin", "valid", "birth_date", "sex", "checksum_ok", "dob_matched", "error_code", "error"}
+
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("validate", stderr)
+	dobFlag := fs.String("dob", "", "birth date to cross-check, YYYY-MM-DD")
+	strict := fs.Bool("strict", false, "treat a DOB mismatch as a validation error")
+	maxAge := fs.Int("max-age", 130, "maximum plausible age in years; 0 disables the cap")
+	lang := fs.String("lang", "", "language of error messages (en, uk)")
+	format := fs.String("format", "text", "output format: text, json or csv")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	dob, err := parseDate(*dobFlag)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	tins, err := inputs(fs.Args(), stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	p, err := newPrinter(*format, stdout, validateHeader, validateText)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	client := uatins.NewClient(
+		uatins.WithStrict(*strict),
+		uatins.WithMaxAge(*maxAge),
+		uatins.WithLanguage(*lang),
+	)
+
+	code := exitOK
+	for _, in := range tins {
+		res, err := client.Validate(in, dob)
+		if err != nil || !res.Valid {
+			code = exitInvalid
+		}
+		if err := p.print(validateRecord(in, res, err, *lang)); err != nil {
+			return fail(stderr, err)
+		}
+	}
+	if err := p.flush(); err != nil {
+		return fail(stderr, err)
+	}
+	return code
+}
+
+func validateRecord(in string, res uatins.Result, err error, lang string) record {
+	var code, msg, birth string
+	if err != nil {
+		code, msg = string(uatins.CodeOf(err)), err.Error()
+	} else if !res.ChecksumOK {
+		code, msg = string(uatins.CodeChecksum), uatins.Localize(uatins.VerifyChecksum(res.TIN), lang)
+	}
+	if !res.BirthDate.IsZero() {
+		birth = res.BirthDate.Format("2006-01-02")
+	}
+	return record{
+		json: validateOutput{Input: in, Result: res, Error: err},
+		cols: []string{
+			in, res.TIN, strconv.FormatBool(res.Valid), birth, string(res.Sex),
+			strconv.FormatBool(res.ChecksumOK), strconv.FormatBool(res.DOBMatched), code, msg,
+		},
+	}
+}
+
+func validateText(r record) string {
+	in, valid, birth, sex, code, msg := r.cols[0], r.cols[2], r.cols[3], r.cols[4], r.cols[7], r.cols[8]
+	if valid == "true" {
+		return fmt.Sprintf("%s\tvalid\t%s\t%s", in, birth, sex)
+	}
+	return fmt.Sprintf("%s\tinvalid\t%s\t%s", in, code, msg)
+}
@@ -0,0 +1,144 @@
+package uatins
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrorCode is a stable, machine-readable identifier of a validation
+// failure. Use it for matching and wire formats; Error.Msg is for humans.
+type ErrorCode string
+
+// Built-in error codes, one per sentinel error.
+const (
+	CodeLength          ErrorCode = "LENGTH"
+	CodeNonDigit        ErrorCode = "NON_DIGIT"
+	CodeAllSame         ErrorCode = "ALL_SAME"
+	CodeChecksum        ErrorCode = "CHECKSUM"
+	CodeBirthOutOfRange ErrorCode = "BIRTH_OUT_OF_RANGE"
+	CodeDOBMismatch     ErrorCode = "DOB_MISMATCH"
+	CodeSexMismatch     ErrorCode = "SEX_MISMATCH"
+	CodeDOBMissing      ErrorCode = "DOB_MISSING"
+	CodeRule            ErrorCode = "RULE"
+	CodeRuleTimeout     ErrorCode = "RULE_TIMEOUT"
+	CodeUnknown         ErrorCode = "UNKNOWN"
+
+	// CodeDOBFormat and CodeBadRequest are shared by the bulk, middleware
+	// and httpapi packages for input that cannot be validated at all.
+	CodeDOBFormat  ErrorCode = "DOB_FORMAT"
+	CodeBadRequest ErrorCode = "BAD_REQUEST"
+)
+
+// codeEntry pairs a code with the sentinel that errors.Is matches it against.
+type codeEntry struct {
+	code     ErrorCode
+	sentinel error
+}
+
+var (
+	codesMu sync.RWMutex
+	codes   = []codeEntry{
+		{CodeLength, ErrLength},
+		{CodeNonDigit, ErrNonDigit},
+		{CodeAllSame, ErrAllSame},
+		{CodeChecksum, ErrChecksum},
+		{CodeBirthOutOfRange, ErrBirthOutOfRange},
+		{CodeDOBMismatch, ErrDOBMismatch},
+		{CodeSexMismatch, ErrSexMismatch},
+		{CodeDOBMissing, ErrDOBMissing},
+		{CodeRule, ErrRule},
+		{CodeRuleTimeout, ErrRuleTimeout},
+		{CodeUnknown, ErrUnknown},
+		{CodeDOBFormat, ErrDOBFormat},
+		{CodeBadRequest, ErrBadRequest},
+	}
+)
+
+// RegisterCode associates a custom code with a sentinel error, so that
+// errors.Is(err, sentinel) holds for any *Error carrying code, and a custom
+// rule returning sentinel (or an error wrapping it) is reported with code.
+// Codes and sentinels must be unique; register them from an init function.
+func RegisterCode(code ErrorCode, sentinel error) error {
+	if code == "" || sentinel == nil {
+		return fmt.Errorf("tin: RegisterCode needs a code and a sentinel")
+	}
+	if !reflect.TypeOf(sentinel).Comparable() {
+		return fmt.Errorf("tin: sentinel for %s must be comparable", code)
+	}
+
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	for _, e := range codes {
+		if e.code == code {
+			return fmt.Errorf("tin: code %s already registered", code)
+		}
+		if e.sentinel == sentinel {
+			return fmt.Errorf("tin: sentinel %q already registered as %s", sentinel, e.code)
+		}
+	}
+	codes = append(codes, codeEntry{code, sentinel})
+	return nil
+}
+
+// MustRegisterCode is like RegisterCode but panics on error.
+func MustRegisterCode(code ErrorCode, sentinel error) {
+	if err := RegisterCode(code, sentinel); err != nil {
+		panic(err)
+	}
+}
+
+// Sentinel returns the sentinel error registered for c, or nil.
+func (c ErrorCode) Sentinel() error {
+	codesMu.RLock()
+	defer codesMu.RUnlock()
+	for _, e := range codes {
+		if e.code == c {
+			return e.sentinel
+		}
+	}
+	return nil
+}
+
+// codeOfSentinel returns the code registered for exactly this sentinel.
+func codeOfSentinel(sentinel error) (ErrorCode, bool) {
+	codesMu.RLock()
+	defer codesMu.RUnlock()
+	for _, e := range codes {
+		if e.sentinel == sentinel {
+			return e.code, true
+		}
+	}
+	return "", false
+}
+
+// CodeOf returns the code of err: the Code of the first *Error in its
+// chain, or the code registered for a sentinel it matches. It returns
+// "" for a nil error and CodeUnknown for anything else.
+func CodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	if code, ok := matchCode(err); ok {
+		return code
+	}
+	return CodeUnknown
+}
+
+// matchCode finds the registered code whose sentinel err matches.
+func matchCode(err error) (ErrorCode, bool) {
+	codesMu.RLock()
+	entries := codes
+	codesMu.RUnlock()
+	for _, e := range entries {
+		if errors.Is(err, e.sentinel) {
+			return e.code, true
+		}
+	}
+	return "", false
+}
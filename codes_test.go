@@ -0,0 +1,70 @@
+package uatins
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errBlockedTest = errors.New("tin: blocked")
+
+func init() {
+	MustRegisterCode("TEST_BLOCKED", errBlockedTest)
+}
+
+func TestErrorCodes_Builtin(t *testing.T) {
+	client := NewClient()
+	cases := map[string]ErrorCode{
+		"12":         CodeLength,
+		"1111111111": CodeAllSame,
+	}
+	for in, want := range cases {
+		_, err := client.Validate(in, nil)
+		if got := CodeOf(err); got != want {
+			t.Errorf("CodeOf(Validate(%q)) = %s, want %s", in, got, want)
+		}
+	}
+
+	if got := CodeOf(VerifyChecksum("3036045687")); got != CodeChecksum {
+		t.Errorf("CodeOf(VerifyChecksum) = %s", got)
+	}
+	if got := CodeOf(fmt.Errorf("wrapped: %w", ErrDOBMismatch)); got != CodeDOBMismatch {
+		t.Errorf("CodeOf(wrapped sentinel) = %s", got)
+	}
+	if CodeOf(nil) != "" || CodeOf(errors.New("other")) != CodeUnknown {
+		t.Error("unexpected code for nil or unregistered error")
+	}
+	for code, sentinel := range map[ErrorCode]error{CodeDOBFormat: ErrDOBFormat, CodeBadRequest: ErrBadRequest} {
+		if !errors.Is(&Error{Code: code}, sentinel) {
+			t.Errorf("%s does not match its sentinel", code)
+		}
+	}
+	if err := (&Error{Code: CodeLength}); err.Error() != ErrLength.Error() {
+		t.Errorf("Error() without Msg = %q", err.Error())
+	}
+}
+
+func TestErrorCodes_Custom(t *testing.T) {
+	client := NewClient(WithRules(Rules[string]{
+		func(string) error { return fmt.Errorf("lookup: %w", errBlockedTest) },
+	}))
+	_, err := client.Validate("3036045681", nil)
+	if CodeOf(err) != "TEST_BLOCKED" || !errors.Is(err, errBlockedTest) {
+		t.Fatalf("expected TEST_BLOCKED, got %s (%v)", CodeOf(err), err)
+	}
+
+	// Matching works on the code alone, e.g. after a JSON round trip.
+	if !errors.Is(&Error{Code: "TEST_BLOCKED"}, errBlockedTest) {
+		t.Fatal("expected *Error with a registered code to match its sentinel")
+	}
+
+	if err := RegisterCode("TEST_BLOCKED", errors.New("x")); err == nil {
+		t.Error("expected duplicate code to be rejected")
+	}
+	if err := RegisterCode("TEST_OTHER", ErrLength); err == nil {
+		t.Error("expected duplicate sentinel to be rejected")
+	}
+	if err := RegisterCode("TEST_SLICE", ValidationErrors{}); err == nil {
+		t.Error("expected non-comparable sentinel to be rejected")
+	}
+}
@@ -0,0 +1,170 @@
+package uatins
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// fuzzNow pins the clock so generated birth dates are always plausible.
+var fuzzNow = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Generate implements quick.Generator: it returns a TIN with a valid
+// checksum and a birth date between 1900-01-01 and fuzzNow.
+func (TIN) Generate(r *rand.Rand, _ int) reflect.Value {
+	days := 1 + r.Intn(DateToDays(fuzzNow)-1)
+	g := NewGenerator(WithSeed(r.Uint64()))
+	return reflect.ValueOf(TIN(g.MustGenerate(DaysToDate(days), "")))
+}
+
+// weightedSum returns the checksum sum of a 9-digit prefix modulo 11.
+func weightedSum(prefix string) int {
+	weights := [...]int{-1, 5, 7, 9, 4, 6, 10, 5, 7}
+	sum := 0
+	for i := range weights {
+		sum += int(prefix[i]-'0') * weights[i]
+	}
+	return (sum%11 + 11) % 11
+}
+
+// checkMutations asserts that every single-digit change of a valid TIN
+// breaks the checksum, except where the weighted sum moves between 0 and
+// 10: both map to check digit 0.
+func checkMutations(t *testing.T, tin string) {
+	t.Helper()
+	orig := weightedSum(tin[:9])
+	for pos := 0; pos < 10; pos++ {
+		for d := byte('0'); d <= '9'; d++ {
+			if tin[pos] == d {
+				continue
+			}
+			m := tin[:pos] + string(d) + tin[pos+1:]
+			if !ChecksumOK(m) {
+				continue
+			}
+			got := weightedSum(m[:9])
+			if pos == 9 || !(orig == 0 && got == 10 || orig == 10 && got == 0) {
+				t.Fatalf("mutation %s of %s keeps the checksum (sums %d, %d)", m, tin, orig, got)
+			}
+		}
+	}
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, s := range []string{"3036045681", "3036-045-681", "3036045687", "", "0000000000", "+1234567890", "３０３６０４５６８１", "\xff\x00"} {
+		f.Add(s)
+	}
+	client := NewClient(WithClock(FixedClock(fuzzNow)))
+	all := NewClient(WithClock(FixedClock(fuzzNow)), WithAllErrors(true))
+	f.Fuzz(func(t *testing.T, s string) {
+		res, err := client.Validate(s, nil)
+		if res.TIN != "" && res.TIN != digitsOnly(s) {
+			t.Fatalf("Validate(%q).TIN = %q, want digits of the input", s, res.TIN)
+		}
+		if res.Valid {
+			if err != nil || len(res.TIN) != 10 || !res.ChecksumOK || !ChecksumOK(res.TIN) {
+				t.Fatalf("Validate(%q) is valid with %+v, %v", s, Reveal(res), err)
+			}
+		}
+		// WithAllErrors also reports checksum failures, so it may add an
+		// error but never drop one or change validity.
+		allRes, allErr := all.Validate(s, nil)
+		if allRes.Valid != res.Valid || (err != nil && allErr == nil) {
+			t.Fatalf("WithAllErrors disagrees on %q: %v/%v vs %v/%v", s, allRes.Valid, allErr, res.Valid, err)
+		}
+	})
+}
+
+func FuzzDigitsOnly(f *testing.F) {
+	for _, s := range []string{"3036-045-681", "", "abc", "٣٠٣", "12\x0034"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d := digitsOnly(s)
+		if strings.Trim(d, "0123456789") != "" {
+			t.Fatalf("digitsOnly(%q) = %q contains non-digits", s, d)
+		}
+		if len(d) > len(s) || digitsOnly(d) != d {
+			t.Fatalf("digitsOnly(%q) = %q is not a filter", s, d)
+		}
+		if strings.Count(s, "0") != strings.Count(d, "0") {
+			t.Fatalf("digitsOnly(%q) = %q dropped digits", s, d)
+		}
+	})
+}
+
+func FuzzDecodeDOBFromTIN(f *testing.F) {
+	for _, s := range []string{"3036045681", "00001", "99999", "1234", "+1234", "-9999x"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := DecodeDOBFromTIN(s)
+		if len(s) < 5 {
+			if err == nil {
+				t.Fatalf("DecodeDOBFromTIN(%q) accepted a short input", s)
+			}
+			return
+		}
+		if digitsOnly(s[:5]) != s[:5] {
+			return
+		}
+		if err != nil {
+			t.Fatalf("DecodeDOBFromTIN(%q): %v", s, err)
+		}
+		if want, _ := strconv.Atoi(s[:5]); DateToDays(d) != want {
+			t.Fatalf("DecodeDOBFromTIN(%q) = %s does not round-trip", s, d)
+		}
+	})
+}
+
+func FuzzChecksumOK(f *testing.F) {
+	for _, s := range []string{"3036045681", "3036045687", "0000000000", "303604568", "30360456811"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		ok := ChecksumOK(s)
+		want := len(s) == 10 && digitsOnly(s) == s && int(s[9]-'0') == weightedSum(s[:9])%10
+		if ok != want {
+			t.Fatalf("ChecksumOK(%q) = %v, want %v", s, ok, want)
+		}
+		if ok {
+			checkMutations(t, s)
+		}
+	})
+}
+
+func TestQuick_GeneratedTINsValidate(t *testing.T) {
+	client := NewClient(WithClock(FixedClock(fuzzNow)), WithStrict(true))
+	valid := func(tin TIN) bool {
+		dob := tin.BirthDate()
+		res, err := client.Validate(string(tin), &dob)
+		return err == nil && res.Valid && res.DOBMatched
+	}
+	if err := quick.Check(valid, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuick_MutationsBreakChecksum(t *testing.T) {
+	mutations := func(tin TIN) bool {
+		checkMutations(t, string(tin))
+		return !t.Failed()
+	}
+	if err := quick.Check(mutations, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuick_ParseRoundTrip(t *testing.T) {
+	roundTrip := func(tin TIN) bool {
+		parsed, err := Parse(tin.String())
+		return err == nil && parsed == tin && !tin.BirthDate().IsZero()
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Fatal(err)
+	}
+}
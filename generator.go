@@ -0,0 +1,115 @@
+package uatins
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// maxSerial is the largest value of the 4-digit serial (digits 6..9).
+const maxSerial = 9999
+
+// Generator produces synthetic TINs with a valid checksum for a given
+// birth date and sex. A Generator is not safe for concurrent use.
+type Generator struct {
+	rng       *rand.Rand
+	serialMin int
+	serialMax int
+}
+
+// GeneratorOption configures a Generator.
+type GeneratorOption func(*Generator)
+
+// NewGenerator returns a Generator seeded from a random source unless
+// WithSeed or WithSource is given.
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	g := &Generator{
+		serialMin: 0,
+		serialMax: maxSerial,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.rng == nil {
+		g.rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return g
+}
+
+// WithSeed makes the Generator deterministic for the given seed.
+func WithSeed(seed uint64) GeneratorOption {
+	return func(g *Generator) {
+		g.rng = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// WithSource uses src as the Generator's randomness source.
+func WithSource(src rand.Source) GeneratorOption {
+	return func(g *Generator) {
+		if src != nil {
+			g.rng = rand.New(src)
+		}
+	}
+}
+
+// WithSerialRange restricts the 4-digit serial (digits 6..9) to [lo, hi].
+// Values are clamped to 0..9999.
+func WithSerialRange(lo, hi int) GeneratorOption {
+	return func(g *Generator) {
+		g.serialMin = max(lo, 0)
+		g.serialMax = min(hi, maxSerial)
+	}
+}
+
+// Generate returns a TIN encoding the calendar date of birthDate (in UTC)
+// and sex. An empty sex picks one at random. The result round-trips through
+// Client.Validate as long as the date is plausible for that client.
+func (g *Generator) Generate(birthDate time.Time, sex Sex) (string, error) {
+	days := DateToDays(birthDate)
+	if days < 1 || days > 99999 {
+		return "", fmt.Errorf("tin: birth date %s cannot be encoded", birthDate.Format("2006-01-02"))
+	}
+
+	var parity int
+	switch sex {
+	case Male:
+		parity = 1
+	case Female:
+		parity = 0
+	case "":
+		parity = g.rng.IntN(2)
+	default:
+		return "", fmt.Errorf("tin: unknown sex %q", sex)
+	}
+
+	if g.serialMin > g.serialMax {
+		return "", fmt.Errorf("tin: empty serial range [%d, %d]", g.serialMin, g.serialMax)
+	}
+
+	// Walk the range from a random starting point so every serial with the
+	// right parity is reachable and the search always terminates.
+	n := g.serialMax - g.serialMin + 1
+	start := g.rng.IntN(n)
+	for i := 0; i < n; i++ {
+		serial := g.serialMin + (start+i)%n
+		if serial%2 != parity {
+			continue
+		}
+		prefix := fmt.Sprintf("%05d%04d", days, serial)
+		tin := prefix + string(rune('0'+checkDigit(prefix)))
+		if ruleNotAllSame()(tin) != nil {
+			continue
+		}
+		return tin, nil
+	}
+	return "", fmt.Errorf("tin: no serial in [%d, %d] matches sex %q", g.serialMin, g.serialMax, sex)
+}
+
+// MustGenerate is like Generate but panics on error.
+func (g *Generator) MustGenerate(birthDate time.Time, sex Sex) string {
+	tin, err := g.Generate(birthDate, sex)
+	if err != nil {
+		panic(err)
+	}
+	return tin
+}
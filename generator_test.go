@@ -0,0 +1,77 @@
+package uatins
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerator_RoundTrip(t *testing.T) {
+	g := NewGenerator(WithSeed(42))
+	client := NewClient(WithStrict(true))
+
+	dates := []time.Time{
+		time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC),
+		time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+	}
+	for _, dob := range dates {
+		for _, sex := range []Sex{Male, Female} {
+			tin, err := g.Generate(dob, sex)
+			if err != nil {
+				t.Fatalf("Generate(%s, %s): %v", dob, sex, err)
+			}
+			res, err := client.Validate(tin, &dob)
+			if err != nil {
+				t.Fatalf("Validate(%s): %v", tin, err)
+			}
+			if !res.Valid || res.Sex != sex || !sameYMD(res.BirthDate, dob) {
+				t.Fatalf("unexpected result for %s: %+v", tin, res)
+			}
+		}
+	}
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	dob := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := NewGenerator(WithSeed(7))
+	b := NewGenerator(WithSeed(7))
+	for i := 0; i < 10; i++ {
+		if x, y := a.MustGenerate(dob, ""), b.MustGenerate(dob, ""); x != y {
+			t.Fatalf("same seed produced %s and %s", x, y)
+		}
+	}
+}
+
+func TestGenerator_SerialRange(t *testing.T) {
+	dob := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithSeed(1), WithSerialRange(120, 125))
+	for i := 0; i < 20; i++ {
+		tin := g.MustGenerate(dob, Female)
+		if s := tin[5:9]; s < "0120" || s > "0125" {
+			t.Fatalf("serial %s outside range", s)
+		}
+	}
+
+	g = NewGenerator(WithSerialRange(4, 4))
+	if _, err := g.Generate(dob, Male); err == nil {
+		t.Fatal("expected error for range without an odd serial")
+	}
+}
+
+func TestGenerator_OutOfRange(t *testing.T) {
+	g := NewGenerator()
+	if _, err := g.Generate(time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC), Male); err == nil {
+		t.Fatal("expected error for date before 1900-01-01")
+	}
+	if _, err := g.Generate(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), Sex("other")); err == nil {
+		t.Fatal("expected error for unknown sex")
+	}
+}
+
+func TestDateToDays(t *testing.T) {
+	for _, days := range []int{1, 29335, 32874, 99999} {
+		if got := DateToDays(DaysToDate(days)); got != days {
+			t.Fatalf("DateToDays(DaysToDate(%d)) = %d", days, got)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Package httpapi exposes a uatins.Client as a JSON-over-HTTP service for
+// callers that are not written in Go. It depends on the standard library
+// only.
+//
+// Routes:
+//
+//	POST /v1/validate         {"tin":"3036045681","dob":"1983-02-14"}
+//	POST /v1/validate/batch   {"items":[{"tin":"..."}, ...]}
+//	GET  /v1/decode/{tin}
+//	POST /v1/generate         {"birth_date":"1990-06-01","sex":"male","count":3}
+//	GET  /openapi.json
+//
+// Results and errors use the JSON encoding of uatins.Result and
+// uatins.Error. A TIN that fails validation is not an HTTP error: the
+// response is 200 with "valid": false and the error alongside. Malformed
+// requests get a 4xx status and an Error object as the body.
+package httpapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+//go:embed openapi.json
+var openAPI []byte
+
+// Error codes used for request errors, alongside the uatins codes. A
+// malformed request is reported as uatins.CodeBadRequest.
+const (
+	CodeTooLarge      uatins.ErrorCode = "TOO_LARGE"
+	CodeUnavailable   uatins.ErrorCode = "UNAVAILABLE"
+	CodeUnprocessable uatins.ErrorCode = "UNPROCESSABLE"
+)
+
+// Handler serves the validation API. It is safe for concurrent use.
+type Handler struct {
+	client      uatins.Validator
+	decoder     uatins.Validator
+	mux         *http.ServeMux
+	maxBatch    int
+	maxGenerate int
+	maxBody     int64
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// New returns a Handler backed by client, which may be a *uatins.Client or
+// any decorated Validator, or by a default client if client is nil.
+// Batches are limited to 1000 items, generate requests to 100 TINs and
+// request bodies to 1 MiB unless overridden.
+func New(client uatins.Validator, opts ...Option) *Handler {
+	if client == nil {
+		client = uatins.NewClient()
+	}
+	h := &Handler{
+		client:      client,
+		maxBatch:    1000,
+		maxGenerate: 100,
+		maxBody:     1 << 20,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.decoder == nil {
+		// Decoding reports what the digits say even for implausible dates.
+		base, ok := client.(*uatins.Client)
+		if !ok {
+			base = uatins.NewClient()
+		}
+		h.decoder = base.DisableRules(uatins.RulePlausible)
+	}
+
+	h.mux = http.NewServeMux()
+	h.mux.HandleFunc("POST /v1/validate", h.validate)
+	h.mux.HandleFunc("POST /v1/validate/batch", h.validateBatch)
+	h.mux.HandleFunc("GET /v1/decode/{tin}", h.decode)
+	h.mux.HandleFunc("POST /v1/generate", h.generate)
+	h.mux.HandleFunc("GET /openapi.json", serveOpenAPI)
+	return h
+}
+
+// WithDecoder sets the Validator behind GET /v1/decode/{tin}. By default
+// it is the Handler's client with uatins.RulePlausible disabled, or a
+// default client with that rule disabled if the Handler's client is not a
+// *uatins.Client.
+func WithDecoder(v uatins.Validator) Option {
+	return func(h *Handler) {
+		h.decoder = v
+	}
+}
+
+// WithMaxBatch limits the number of items in one batch request.
+func WithMaxBatch(n int) Option {
+	return func(h *Handler) {
+		h.maxBatch = n
+	}
+}
+
+// WithMaxGenerate limits the number of TINs one generate request may ask for.
+func WithMaxGenerate(n int) Option {
+	return func(h *Handler) {
+		h.maxGenerate = n
+	}
+}
+
+// WithMaxBodyBytes limits the size of request bodies.
+func WithMaxBodyBytes(n int64) Option {
+	return func(h *Handler) {
+		h.maxBody = n
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// OpenAPI returns the OpenAPI 3 document describing the API.
+func OpenAPI() []byte {
+	return append([]byte(nil), openAPI...)
+}
+
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPI)
+}
+
+// validateRequest is the body of POST /v1/validate and one batch item.
+type validateRequest struct {
+	TIN string `json:"tin"`
+	DOB string `json:"dob,omitempty"`
+}
+
+// validateResponse is the body returned by POST /v1/validate.
+type validateResponse struct {
+	Result uatins.Result `json:"result"`
+	Error  any           `json:"error,omitempty"`
+}
+
+func (h *Handler) validate(w http.ResponseWriter, r *http.Request) {
+	var req validateRequest
+	if !h.readJSON(w, r, &req) {
+		return
+	}
+	item, err := req.item()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, uatins.CodeBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.client.ValidateContext(r.Context(), item.TIN, item.DOB)
+	if ctxErr := r.Context().Err(); ctxErr != nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, ctxErr.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, validateResponse{Result: res, Error: encodeError(err)})
+}
+
+type batchRequest struct {
+	Items []validateRequest `json:"items"`
+}
+
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+type batchResult struct {
+	Index  int           `json:"index"`
+	Result uatins.Result `json:"result"`
+	Error  any           `json:"error,omitempty"`
+}
+
+func (h *Handler) validateBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if !h.readJSON(w, r, &req) {
+		return
+	}
+	if len(req.Items) > h.maxBatch {
+		writeError(w, http.StatusRequestEntityTooLarge, CodeTooLarge,
+			fmt.Sprintf("batch has %d items, the limit is %d", len(req.Items), h.maxBatch))
+		return
+	}
+	items := make([]uatins.BatchItem, len(req.Items))
+	for i, it := range req.Items {
+		item, err := it.item()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, uatins.CodeBadRequest, fmt.Sprintf("items[%d]: %v", i, err))
+			return
+		}
+		items[i] = item
+	}
+
+	results, err := h.client.ValidateBatch(r.Context(), items)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, err.Error())
+		return
+	}
+	out := batchResponse{Results: make([]batchResult, len(results))}
+	for i, br := range results {
+		out.Results[i] = batchResult{Index: br.Index, Result: br.Result, Error: encodeError(br.Err)}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// decodeResponse is the body returned by GET /v1/decode/{tin}.
+type decodeResponse struct {
+	TIN        string     `json:"tin"`
+	BirthDate  string     `json:"birth_date"`
+	Sex        uatins.Sex `json:"sex"`
+	ChecksumOK bool       `json:"checksum_ok"`
+}
+
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request) {
+	res, err := h.decoder.ValidateContext(r.Context(), r.PathValue("tin"), nil)
+	switch ctxErr := r.Context().Err(); {
+	case ctxErr != nil:
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, ctxErr.Error())
+	case err != nil:
+		writeJSON(w, http.StatusUnprocessableEntity, encodeError(err))
+	default:
+		writeJSON(w, http.StatusOK, decodeResponse{
+			TIN:        res.TIN,
+			BirthDate:  res.BirthDate.Format("2006-01-02"),
+			Sex:        res.Sex,
+			ChecksumOK: res.ChecksumOK,
+		})
+	}
+}
+
+type generateRequest struct {
+	BirthDate string     `json:"birth_date"`
+	Sex       uatins.Sex `json:"sex,omitempty"`
+	Count     int        `json:"count,omitempty"`
+	Seed      uint64     `json:"seed,omitempty"`
+}
+
+type generateResponse struct {
+	TINs []string `json:"tins"`
+}
+
+func (h *Handler) generate(w http.ResponseWriter, r *http.Request) {
+	var req generateRequest
+	if !h.readJSON(w, r, &req) {
+		return
+	}
+	birth, err := time.Parse("2006-01-02", req.BirthDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, uatins.CodeBadRequest, fmt.Sprintf("invalid birth_date %q, want YYYY-MM-DD", req.BirthDate))
+		return
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+	if req.Count < 0 || req.Count > h.maxGenerate {
+		writeError(w, http.StatusBadRequest, uatins.CodeBadRequest, fmt.Sprintf("count must be between 1 and %d", h.maxGenerate))
+		return
+	}
+	if req.Seed == 0 {
+		req.Seed = rand.Uint64()
+	}
+
+	g := uatins.NewGenerator(uatins.WithSeed(req.Seed))
+	out := generateResponse{TINs: make([]string, req.Count)}
+	for i := range out.TINs {
+		if out.TINs[i], err = g.Generate(birth, req.Sex); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, CodeUnprocessable, err.Error())
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// item converts a request into a BatchItem, parsing the optional DOB.
+func (req validateRequest) item() (uatins.BatchItem, error) {
+	item := uatins.BatchItem{TIN: req.TIN}
+	if req.DOB != "" {
+		dob, err := time.Parse("2006-01-02", req.DOB)
+		if err != nil {
+			return item, fmt.Errorf("invalid dob %q, want YYYY-MM-DD", req.DOB)
+		}
+		item.DOB = &dob
+	}
+	return item, nil
+}
+
+// readJSON decodes the request body into v, writing an error response and
+// returning false if it cannot.
+func (h *Handler) readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBody))
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, CodeTooLarge, "request body too large")
+		} else {
+			writeError(w, http.StatusBadRequest, uatins.CodeBadRequest, "invalid JSON body: "+err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// encodeError returns err in a JSON-encodable form: nil, an *uatins.Error
+// or uatins.ValidationErrors as they are, anything else as an Error with
+// its code and message.
+func encodeError(err error) any {
+	var e *uatins.Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, new(uatins.ValidationErrors)):
+		return err
+	case errors.As(err, &e):
+		return e
+	default:
+		return &uatins.Error{Code: uatins.CodeOf(err), Msg: err.Error()}
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code uatins.ErrorCode, msg string) {
+	writeJSON(w, status, &uatins.Error{Code: code, Msg: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,170 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/uatins"
+	"github.com/stremovskyy/uatins/uatinstest"
+)
+
+func do(t *testing.T, h http.Handler, method, path, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	var out map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+	}
+	return rec, out
+}
+
+func TestValidate(t *testing.T) {
+	h := New(uatins.NewClient(uatins.WithStrict(true)))
+
+	rec, out := do(t, h, "POST", "/v1/validate", `{"tin":"3036045681","dob":"1983-02-14"}`)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	res := out["result"].(map[string]any)
+	if res["valid"] != true || res["sex"] != "female" || out["error"] != nil {
+		t.Fatalf("unexpected body %v", out)
+	}
+
+	rec, out = do(t, h, "POST", "/v1/validate", `{"tin":"3036045681","dob":"1983-02-15"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("an invalid TIN is not an HTTP error, got %d", rec.Code)
+	}
+	if e := out["error"].(map[string]any); e["code"] != "DOB_MISMATCH" || e["provided_dob"] != "1983-02-15" {
+		t.Fatalf("unexpected error %v", e)
+	}
+
+	for _, body := range []string{`{"tin":`, `{"tin":"3036045681","dob":"14.02.1983"}`} {
+		if rec, out := do(t, h, "POST", "/v1/validate", body); rec.Code != http.StatusBadRequest || out["code"] != "BAD_REQUEST" {
+			t.Errorf("%s: status %d, body %v", body, rec.Code, out)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/validate", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /v1/validate: status %d", rec.Code)
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	h := New(nil, WithMaxBatch(3))
+
+	rec, out := do(t, h, "POST", "/v1/validate/batch", `{"items":[{"tin":"3036045681"},{"tin":"12"},{"tin":"3036045687"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	results := out["results"].([]any)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", results)
+	}
+	codes := []any{nil, "LENGTH", nil}
+	for i, r := range results {
+		r := r.(map[string]any)
+		if r["index"] != float64(i) {
+			t.Errorf("result %d has index %v", i, r["index"])
+		}
+		var code any
+		if e, ok := r["error"].(map[string]any); ok {
+			code = e["code"]
+		}
+		if code != codes[i] {
+			t.Errorf("result %d: code %v, want %v", i, code, codes[i])
+		}
+	}
+	if results[2].(map[string]any)["result"].(map[string]any)["valid"] != false {
+		t.Error("a checksum mismatch must not be valid")
+	}
+
+	rec, out = do(t, h, "POST", "/v1/validate/batch", `{"items":[{},{},{},{}]}`)
+	if rec.Code != http.StatusRequestEntityTooLarge || out["code"] != "TOO_LARGE" {
+		t.Fatalf("oversized batch: status %d, body %v", rec.Code, out)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	h := New(nil)
+
+	rec, out := do(t, h, "GET", "/v1/decode/3036045687", "")
+	if rec.Code != http.StatusOK || out["birth_date"] != "1983-02-14" || out["sex"] != "female" || out["checksum_ok"] != false {
+		t.Fatalf("status %d, body %v", rec.Code, out)
+	}
+
+	rec, out = do(t, h, "GET", "/v1/decode/0000000000", "")
+	if rec.Code != http.StatusUnprocessableEntity || out["code"] != "ALL_SAME" {
+		t.Fatalf("status %d, body %v", rec.Code, out)
+	}
+}
+
+func TestDecorated(t *testing.T) {
+	h := New(uatins.Cached(uatins.NewClient(), 10, 0))
+	if rec, out := do(t, h, "POST", "/v1/validate", `{"tin":"3036045681"}`); rec.Code != http.StatusOK || out["result"].(map[string]any)["valid"] != true {
+		t.Fatalf("status %d, body %v", rec.Code, out)
+	}
+	if rec, out := do(t, h, "GET", "/v1/decode/3036045681", ""); rec.Code != http.StatusOK || out["sex"] != "female" {
+		t.Fatalf("status %d, body %v", rec.Code, out)
+	}
+
+	fake := uatinstest.NewFake().Fail("3036045681", uatins.CodeAllSame)
+	h = New(nil, WithDecoder(fake))
+	if rec, out := do(t, h, "GET", "/v1/decode/3036045681", ""); rec.Code != http.StatusUnprocessableEntity || out["code"] != "ALL_SAME" {
+		t.Fatalf("decoder not used: status %d, body %v", rec.Code, out)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	h := New(nil, WithMaxGenerate(5))
+
+	rec, out := do(t, h, "POST", "/v1/generate", `{"birth_date":"1990-06-01","sex":"male","count":5,"seed":7}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %v", rec.Code, out)
+	}
+	tins := out["tins"].([]any)
+	if len(tins) != 5 {
+		t.Fatalf("expected 5 TINs, got %v", tins)
+	}
+	for _, tin := range tins {
+		tin, err := uatins.Parse(tin.(string))
+		if err != nil || tin.Sex() != uatins.Male {
+			t.Errorf("generated %s: %v", tin, err)
+		}
+	}
+	_, again := do(t, h, "POST", "/v1/generate", `{"birth_date":"1990-06-01","sex":"male","count":5,"seed":7}`)
+	if len(again["tins"].([]any)) != 5 || again["tins"].([]any)[0] != tins[0] {
+		t.Error("the same seed must produce the same TINs")
+	}
+
+	for _, body := range []string{
+		`{"birth_date":"1990-06-01","count":6}`,
+		`{"birth_date":"1990-06-01","sex":"other"}`,
+		`{"sex":"male"}`,
+	} {
+		if rec, _ := do(t, h, "POST", "/v1/generate", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d", body, rec.Code)
+		}
+	}
+	if rec, out := do(t, h, "POST", "/v1/generate", `{"birth_date":"1850-01-01"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unencodable date: status %d, body %v", rec.Code, out)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	rec, out := do(t, New(nil), "GET", "/openapi.json", "")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(out["openapi"].(string), "3.") {
+		t.Fatalf("status %d, openapi %v", rec.Code, out["openapi"])
+	}
+	paths := out["paths"].(map[string]any)
+	for _, p := range []string{"/v1/validate", "/v1/validate/batch", "/v1/decode/{tin}", "/v1/generate"} {
+		if paths[p] == nil {
+			t.Errorf("OpenAPI document misses %s", p)
+		}
+	}
+}
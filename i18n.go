@@ -0,0 +1,223 @@
+package uatins
+
+import (
+	"errors"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Catalog holds the message templates of one language, keyed by error code.
+// Templates may reference parameters in braces: {tin}, {rule},
+// {expected_digit}, {actual_digit}, {decoded_dob}, {provided_dob}, plus any
+// key of Error.Params such as {length}. Dates are formatted with DateLayout.
+type Catalog struct {
+	DateLayout string
+	Messages   map[ErrorCode]string
+}
+
+// Built-in languages.
+const (
+	LangEnglish   = "en"
+	LangUkrainian = "uk"
+)
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Catalog{
+		LangEnglish: {
+			DateLayout: "2006-01-02",
+			Messages: map[ErrorCode]string{
+				CodeLength:          "need {length} digits",
+				CodeNonDigit:        "only digits allowed",
+				CodeAllSame:         "implausible: all digits identical or zero",
+				CodeChecksum:        "checksum mismatch: expected {expected_digit}, got {actual_digit}",
+				CodeBirthOutOfRange: "encoded birth date out of plausible range",
+				CodeDOBMismatch:     "provided DOB does not match encoded date",
+				CodeSexMismatch:     "provided sex does not match encoded sex",
+				CodeDOBMissing:      "birth date is missing",
+				CodeRuleTimeout:     "rule {rule} timed out",
+				CodeUnknown:         "tin is not valid",
+			},
+		},
+		LangUkrainian: {
+			DateLayout: "02.01.2006",
+			Messages: map[ErrorCode]string{
+				CodeLength:          "РНОКПП має містити {length} цифр",
+				CodeNonDigit:        "РНОКПП може містити лише цифри",
+				CodeAllSame:         "РНОКПП не може складатися з однакових цифр",
+				CodeChecksum:        "неправильна контрольна цифра: очікувалася {expected_digit}, отримано {actual_digit}",
+				CodeBirthOutOfRange: "закодована дата народження {decoded_dob} неправдоподібна",
+				CodeDOBMismatch:     "вказана дата народження {provided_dob} не збігається із закодованою {decoded_dob}",
+				CodeSexMismatch:     "вказана стать не збігається із закодованою в РНОКПП",
+				CodeDOBMissing:      "не вказано дату народження",
+				CodeRuleTimeout:     "перевірка {rule} не завершилася вчасно",
+				CodeUnknown:         "РНОКПП недійсний",
+			},
+		},
+	}
+)
+
+// RegisterCatalog adds a language or extends an existing one; messages in
+// cat override those already registered for the same codes. Use it to
+// translate custom error codes.
+func RegisterCatalog(lang string, cat Catalog) {
+	lang = strings.ToLower(lang)
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	cur, ok := catalogs[lang]
+	if !ok {
+		cur = Catalog{DateLayout: dateLayout}
+	}
+	msgs := maps.Clone(cur.Messages)
+	if msgs == nil {
+		msgs = make(map[ErrorCode]string, len(cat.Messages))
+	}
+	maps.Copy(msgs, cat.Messages)
+	cur.Messages = msgs
+	if cat.DateLayout != "" {
+		cur.DateLayout = cat.DateLayout
+	}
+	catalogs[lang] = cur
+}
+
+// lookupCatalog finds the catalog for lang, falling back from a regional
+// tag such as "uk-UA" to its base language.
+func lookupCatalog(lang string) (Catalog, bool) {
+	lang = strings.ToLower(lang)
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	if cat, ok := catalogs[lang]; ok {
+		return cat, true
+	}
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		cat, ok := catalogs[lang[:i]]
+		return cat, ok
+	}
+	return Catalog{}, false
+}
+
+// Localize renders e in lang using the registered catalogs. It falls back
+// to English when no template exists for e.Code, and to e.Error() when
+// there is none at all or e lacks a value the template needs.
+func (e *Error) Localize(lang string) string {
+	cat, ok := lookupCatalog(lang)
+	tmpl := cat.Messages[e.Code]
+	if !ok || tmpl == "" {
+		cat, _ = lookupCatalog(LangEnglish)
+		tmpl = cat.Messages[e.Code]
+	}
+	if tmpl == "" {
+		return e.Error()
+	}
+
+	params := map[string]string{
+		"tin":  e.TIN,
+		"rule": e.Rule,
+	}
+	if e.ExpectedDigit != nil {
+		params["expected_digit"] = strconv.Itoa(*e.ExpectedDigit)
+	}
+	if e.ActualDigit != nil {
+		params["actual_digit"] = strconv.Itoa(*e.ActualDigit)
+	}
+	if e.DecodedDOB != nil {
+		params["decoded_dob"] = e.DecodedDOB.UTC().Format(cat.DateLayout)
+	}
+	if e.ProvidedDOB != nil {
+		params["provided_dob"] = e.ProvidedDOB.UTC().Format(cat.DateLayout)
+	}
+	maps.Copy(params, e.Params)
+
+	msg, ok := render(tmpl, params)
+	if !ok {
+		return e.Error()
+	}
+	return msg
+}
+
+// render fills the {name} placeholders of tmpl from params. ok is false if
+// a placeholder has no value, e.g. for an *Error built without Params.
+func render(tmpl string, params map[string]string) (msg string, ok bool) {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		end := strings.IndexByte(tmpl[max(start, 0):], '}')
+		if start < 0 || end < 0 {
+			b.WriteString(tmpl)
+			return b.String(), true
+		}
+		end += start
+		v := params[tmpl[start+1:end]]
+		if v == "" {
+			return "", false
+		}
+		b.WriteString(tmpl[:start])
+		b.WriteString(v)
+		tmpl = tmpl[end+1:]
+	}
+}
+
+// Localize renders err in lang. Every *Error inside err is localized;
+// entries of a ValidationErrors are joined by newlines like Error(), and a
+// *FieldError keeps its field path.
+func Localize(err error, lang string) string {
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		msgs := make([]string, len(errs))
+		for i, e := range errs {
+			msgs[i] = Localize(e, lang)
+		}
+		return strings.Join(msgs, "\n")
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe.Field + ": " + Localize(fe.Err, lang)
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Localize(lang)
+	}
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// WithLanguage makes Validate report messages in lang (e.g. "uk" or "en").
+// The empty string keeps the default English messages.
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.lang = lang
+	}
+}
+
+// Language makes Validate report messages in lang. Returns a derived client for chaining.
+func (c *Client) Language(lang string) *Client {
+	return c.derive(WithLanguage(lang))
+}
+
+// localize rewrites the message of every *Error in err for the client's
+// language. Errors are copied, never modified in place.
+func (c *Client) localize(err error) error {
+	if c.lang == "" {
+		return err
+	}
+	switch e := err.(type) {
+	case *Error:
+		cp := *e
+		cp.Msg = e.Localize(c.lang)
+		return &cp
+	case ValidationErrors:
+		out := make(ValidationErrors, len(e))
+		for i, err := range e {
+			out[i] = c.localize(err)
+		}
+		return out
+	default:
+		return err
+	}
+}
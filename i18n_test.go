@@ -0,0 +1,91 @@
+package uatins
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalize_EnglishMatchesDefaults(t *testing.T) {
+	dob := time.Date(1983, 2, 15, 0, 0, 0, 0, time.UTC)
+	strict := NewClient(WithStrict(true))
+	inputs := []string{"12", "1111111111", "3036045681"}
+	for _, in := range inputs {
+		_, err := strict.Validate(in, &dob)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Fatalf("Validate(%q): expected *Error, got %v", in, err)
+		}
+		if got := e.Localize(LangEnglish); got != e.Error() {
+			t.Errorf("%s: English catalog %q differs from default %q", e.Code, got, e.Error())
+		}
+	}
+
+	var e *Error
+	errors.As(VerifyChecksum("3036045687"), &e)
+	if got := e.Localize(LangEnglish); got != e.Error() {
+		t.Errorf("CHECKSUM: English catalog %q differs from default %q", got, e.Error())
+	}
+}
+
+func TestLocalize_Ukrainian(t *testing.T) {
+	client := NewClient(WithLanguage("uk-UA"), WithStrict(true))
+
+	_, err := client.Validate("12", nil)
+	if err == nil || err.Error() != "РНОКПП має містити 10 цифр" {
+		t.Fatalf("unexpected message: %v", err)
+	}
+	if !errorsIs(err, ErrLength) {
+		t.Fatalf("localized error lost its code: %v", err)
+	}
+
+	dob := time.Date(1983, 2, 15, 0, 0, 0, 0, time.UTC)
+	_, err = client.Validate("3036045681", &dob)
+	want := "вказана дата народження 15.02.1983 не збігається із закодованою 14.02.1983"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got %v, want %q", err, want)
+	}
+
+	got := Localize(VerifyChecksum("3036045687"), LangUkrainian)
+	if got != "неправильна контрольна цифра: очікувалася 1, отримано 7" {
+		t.Fatalf("unexpected checksum message: %q", got)
+	}
+}
+
+func TestLocalize_ValidationErrors(t *testing.T) {
+	client := NewClient(WithAllErrors(true)).Language(LangUkrainian)
+	_, err := client.Validate("3036045687", nil)
+	if err == nil || !strings.Contains(err.Error(), "контрольна цифра") {
+		t.Fatalf("expected localized collected errors, got %v", err)
+	}
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	RegisterCatalog("uk", Catalog{Messages: map[ErrorCode]string{
+		"TEST_BLOCKED": "РНОКПП {tin} заблоковано",
+	}})
+
+	e := &Error{Code: "TEST_BLOCKED", TIN: "3036045681", Msg: "blocked"}
+	if got := e.Localize("uk"); got != "РНОКПП 3036045681 заблоковано" {
+		t.Fatalf("unexpected custom message: %q", got)
+	}
+	// No English template: fall back to the error's own message.
+	if got := e.Localize("en"); got != "blocked" {
+		t.Fatalf("unexpected fallback: %q", got)
+	}
+	// Registering a code must not drop the built-in messages.
+	if got := (&Error{Code: CodeNonDigit}).Localize("uk"); got != "РНОКПП може містити лише цифри" {
+		t.Fatalf("built-in message lost: %q", got)
+	}
+}
+
+func TestLocalize_MissingParams(t *testing.T) {
+	e := &Error{Code: CodeChecksum}
+	if got := e.Localize(LangUkrainian); got != e.Error() {
+		t.Fatalf("unresolved placeholders must fall back to Error(), got %q", got)
+	}
+	if got := (&Error{Code: CodeLength, Params: map[string]string{"length": "10"}}).Localize(LangUkrainian); got != "РНОКПП має містити 10 цифр" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
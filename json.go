@@ -0,0 +1,213 @@
+package uatins
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// dateLayout is the ISO-8601 calendar date format used on the wire.
+const dateLayout = "2006-01-02"
+
+// jsonDate encodes a time.Time as an ISO-8601 date without a time component.
+// The date is taken in UTC, the same way Validate compares birth dates.
+type jsonDate time.Time
+
+func (d jsonDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(d).UTC().Format(dateLayout))
+}
+
+func (d *jsonDate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return fmt.Errorf("tin: invalid date %q, want YYYY-MM-DD", s)
+	}
+	*d = jsonDate(t)
+	return nil
+}
+
+// toJSONDate returns nil for a nil or zero time.
+func toJSONDate(t *time.Time) *jsonDate {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	d := jsonDate(*t)
+	return &d
+}
+
+// fromJSONDate returns nil for a nil date.
+func fromJSONDate(d *jsonDate) *time.Time {
+	if d == nil {
+		return nil
+	}
+	t := time.Time(*d)
+	return &t
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting "male",
+// "female" or the empty string.
+func (s *Sex) UnmarshalText(text []byte) error {
+	switch v := Sex(text); v {
+	case Male, Female, "":
+		*s = v
+		return nil
+	default:
+		return fmt.Errorf("tin: unknown sex %q", v)
+	}
+}
+
+// resultJSON is the wire format of Result.
+type resultJSON struct {
+	TIN                string    `json:"tin"`
+	BirthDate          *jsonDate `json:"birth_date,omitempty"`
+	Sex                Sex       `json:"sex,omitempty"`
+	ChecksumOK         bool      `json:"checksum_ok"`
+	BirthDatePlausible bool      `json:"birth_date_plausible"`
+	DOBMatched         bool      `json:"dob_matched"`
+	Valid              bool      `json:"valid"`
+	ProvidedDOB        *jsonDate `json:"provided_dob,omitempty"`
+}
+
+// MarshalJSON encodes r with snake_case fields and dates as YYYY-MM-DD:
+//
+//	{"tin":"3036045681","birth_date":"1983-02-14","sex":"female",
+//	 "checksum_ok":true,"birth_date_plausible":true,"dob_matched":true,
+//	 "valid":true,"provided_dob":"1983-02-14"}
+//
+// birth_date, sex and provided_dob are omitted when unknown.
+func (r Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resultJSON{
+		TIN:                r.TIN,
+		BirthDate:          toJSONDate(&r.BirthDate),
+		Sex:                r.Sex,
+		ChecksumOK:         r.ChecksumOK,
+		BirthDatePlausible: r.BirthDatePlausible,
+		DOBMatched:         r.DOBMatched,
+		Valid:              r.Valid,
+		ProvidedDOB:        toJSONDate(r.ProvidedDOB),
+	})
+}
+
+// UnmarshalJSON decodes the format written by MarshalJSON. Dates are
+// restored as UTC midnight.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var w resultJSON
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	*r = Result{
+		TIN:                w.TIN,
+		Sex:                w.Sex,
+		ChecksumOK:         w.ChecksumOK,
+		BirthDatePlausible: w.BirthDatePlausible,
+		DOBMatched:         w.DOBMatched,
+		Valid:              w.Valid,
+		ProvidedDOB:        fromJSONDate(w.ProvidedDOB),
+	}
+	if w.BirthDate != nil {
+		r.BirthDate = time.Time(*w.BirthDate)
+	}
+	return nil
+}
+
+// errorJSON is the wire format of Error.
+type errorJSON struct {
+	Code          ErrorCode         `json:"code"`
+	Message       string            `json:"message"`
+	TIN           string            `json:"tin,omitempty"`
+	Rule          string            `json:"rule,omitempty"`
+	Params        map[string]string `json:"params,omitempty"`
+	DecodedDOB    *jsonDate         `json:"decoded_dob,omitempty"`
+	ProvidedDOB   *jsonDate         `json:"provided_dob,omitempty"`
+	ExpectedDigit *int              `json:"expected_digit,omitempty"`
+	ActualDigit   *int              `json:"actual_digit,omitempty"`
+}
+
+// MarshalJSON encodes e with a machine-readable code next to the message:
+//
+//	{"code":"CHECKSUM","message":"checksum mismatch: expected 1, got 7",
+//	 "tin":"3036045687","rule":"checksum","expected_digit":1,"actual_digit":7}
+//
+// Optional fields are omitted when unset. The wrapped Err is not encoded.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.wire())
+}
+
+// wire returns the wire format of e.
+func (e *Error) wire() errorJSON {
+	return errorJSON{
+		Code:          e.Code,
+		Message:       e.Error(),
+		TIN:           e.TIN,
+		Rule:          e.Rule,
+		Params:        e.Params,
+		DecodedDOB:    toJSONDate(e.DecodedDOB),
+		ProvidedDOB:   toJSONDate(e.ProvidedDOB),
+		ExpectedDigit: e.ExpectedDigit,
+		ActualDigit:   e.ActualDigit,
+	}
+}
+
+// UnmarshalJSON decodes the format written by MarshalJSON.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var w errorJSON
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	*e = Error{
+		Code:          w.Code,
+		TIN:           w.TIN,
+		Msg:           w.Message,
+		Rule:          w.Rule,
+		Params:        w.Params,
+		DecodedDOB:    fromJSONDate(w.DecodedDOB),
+		ProvidedDOB:   fromJSONDate(w.ProvidedDOB),
+		ExpectedDigit: w.ExpectedDigit,
+		ActualDigit:   w.ActualDigit,
+	}
+	return nil
+}
+
+// MarshalJSON encodes e as an array of Error objects; a *FieldError keeps
+// its "field" member. Other errors are encoded with CodeOf(err) and their
+// message.
+func (e ValidationErrors) MarshalJSON() ([]byte, error) {
+	out := make([]any, len(e))
+	for i, err := range e {
+		var fe *FieldError
+		var te *Error
+		switch {
+		case errors.As(err, &fe):
+			out[i] = fe
+		case errors.As(err, &te):
+			out[i] = te
+		default:
+			out[i] = &Error{Code: CodeOf(err), Msg: err.Error()}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes an array of Error objects.
+func (e *ValidationErrors) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*e = nil
+		return nil
+	}
+	var in []*Error
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	out := make(ValidationErrors, len(in))
+	for i, te := range in {
+		out[i] = te
+	}
+	*e = out
+	return nil
+}
@@ -0,0 +1,91 @@
+package uatins
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResult_JSON(t *testing.T) {
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	res, err := NewClient(WithLocation(time.FixedZone("EET", 2*3600))).Validate("3036045681", &dob)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	out, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"tin":"3036045681","birth_date":"1983-02-14","sex":"female","checksum_ok":true,` +
+		`"birth_date_plausible":true,"dob_matched":true,"valid":true,"provided_dob":"1983-02-14"}`
+	if string(out) != want {
+		t.Fatalf("Marshal =\n%s\nwant\n%s", out, want)
+	}
+
+	var back Result
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if back.TIN != res.TIN || !back.BirthDate.Equal(dob) || back.Sex != Female || !back.Valid ||
+		back.ProvidedDOB == nil || !back.ProvidedDOB.Equal(dob) {
+		t.Fatalf("round trip mismatch: %+v", back)
+	}
+
+	out, _ = json.Marshal(Result{TIN: "12"})
+	if string(out) != `{"tin":"12","checksum_ok":false,"birth_date_plausible":false,"dob_matched":false,"valid":false}` {
+		t.Fatalf("unexpected encoding of an undecoded result: %s", out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"sex":"other"}`), &back); err == nil {
+		t.Fatal("expected unknown sex to be rejected")
+	}
+	if err := json.Unmarshal([]byte(`{"birth_date":"14.02.1983"}`), &back); err == nil {
+		t.Fatal("expected a non-ISO date to be rejected")
+	}
+}
+
+func TestError_JSON(t *testing.T) {
+	err := VerifyChecksum("3036045687")
+	out, mErr := json.Marshal(err)
+	if mErr != nil {
+		t.Fatalf("Marshal: %v", mErr)
+	}
+	want := `{"code":"CHECKSUM","message":"checksum mismatch: expected 1, got 7",` +
+		`"tin":"3036045687","expected_digit":1,"actual_digit":7}`
+	if string(out) != want {
+		t.Fatalf("Marshal =\n%s\nwant\n%s", out, want)
+	}
+
+	var back Error
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !errors.Is(&back, ErrChecksum) || *back.ExpectedDigit != 1 || back.Error() != err.Error() {
+		t.Fatalf("round trip mismatch: %+v", back)
+	}
+}
+
+func TestValidationErrors_JSON(t *testing.T) {
+	client := NewClient(WithAllErrors(true), WithStrict(true))
+	dob := time.Date(1983, 2, 15, 0, 0, 0, 0, time.UTC)
+	_, err := client.Validate("3036045687", &dob)
+
+	out, mErr := json.Marshal(err)
+	if mErr != nil {
+		t.Fatalf("Marshal: %v", mErr)
+	}
+	var back ValidationErrors
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !errors.Is(back, ErrChecksum) || !errors.Is(back, ErrDOBMismatch) {
+		t.Fatalf("round trip lost errors: %s", out)
+	}
+
+	var mm *Error
+	if !errors.As(back, &mm) || mm.Rule != RuleChecksum {
+		t.Fatalf("expected rule names to survive, got %s", out)
+	}
+}
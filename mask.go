@@ -0,0 +1,300 @@
+package uatins
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// MaskStyle selects how a TIN is redacted for logs and display.
+type MaskStyle int32
+
+const (
+	// MaskEdges keeps the first two and last two digits: 30******81.
+	MaskEdges MaskStyle = iota
+	// MaskKeepBirth keeps the five-digit birth date segment: 30360*****.
+	MaskKeepBirth
+	// MaskFull hides every digit: **********.
+	MaskFull
+	// MaskNone leaves the TIN as is.
+	MaskNone
+)
+
+// maskStyle is the style used by the fmt and slog integrations.
+var maskStyle atomic.Int32
+
+// SetMaskStyle sets the style used when a Result or Error is formatted
+// with fmt or logged with slog. The default is MaskEdges. Setting
+// MaskNone disables masking process-wide; prefer Reveal for single values.
+func SetMaskStyle(s MaskStyle) {
+	maskStyle.Store(int32(s))
+}
+
+func currentMaskStyle() MaskStyle {
+	return MaskStyle(maskStyle.Load())
+}
+
+// Mask redacts tin in the given style. Inputs too short to keep any
+// characters under MaskEdges or MaskKeepBirth are hidden entirely.
+func Mask(tin string, style MaskStyle) string {
+	if tin == "" {
+		return ""
+	}
+	keepHead, keepTail := 0, 0
+	switch style {
+	case MaskNone:
+		return tin
+	case MaskEdges:
+		if len(tin) > 4 {
+			keepHead, keepTail = 2, 2
+		}
+	case MaskKeepBirth:
+		if len(tin) > 5 {
+			keepHead = 5
+		}
+	}
+	return tin[:keepHead] + strings.Repeat("*", len(tin)-keepHead-keepTail) + tin[len(tin)-keepTail:]
+}
+
+// Reveal returns a wrapper that formats v unmasked with fmt and slog.
+// It is the explicit opt-in for logging a full TIN:
+//
+//	log.Printf("%v", res)                // {30******81 ...}
+//	log.Printf("%v", uatins.Reveal(res)) // {3036045681 ...}
+//
+// Values other than Result, *Error, *FieldError and ValidationErrors are
+// formatted as usual.
+func Reveal(v any) Revealed {
+	return Revealed{v}
+}
+
+// Revealed formats the wrapped value without masking. See Reveal.
+type Revealed struct {
+	v any
+}
+
+// Format implements fmt.Formatter.
+func (r Revealed) Format(f fmt.State, verb rune) {
+	if m, ok := r.v.(maskFormatter); ok {
+		m.format(f, verb, MaskNone)
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), r.v)
+}
+
+// LogValue implements slog.LogValuer.
+func (r Revealed) LogValue() slog.Value {
+	if m, ok := r.v.(maskFormatter); ok {
+		return m.logValue(MaskNone)
+	}
+	return slog.AnyValue(r.v)
+}
+
+// maskFormatter is implemented by the types that mask themselves.
+type maskFormatter interface {
+	format(f fmt.State, verb rune, style MaskStyle)
+	logValue(style MaskStyle) slog.Value
+}
+
+// plainResult is Result without its Format method.
+type plainResult Result
+
+// maskedResult mirrors the fields of Result for masked output, with the
+// birth dates and sex replaced by a placeholder.
+type maskedResult struct {
+	TIN                string
+	BirthDate          redacted
+	Sex                redacted
+	ChecksumOK         bool
+	BirthDatePlausible bool
+	DOBMatched         bool
+	Valid              bool
+	ProvidedDOB        any // a nil *time.Time or redacted
+}
+
+// redacted prints as <redacted> in place of a hidden value.
+type redacted struct{}
+
+func (redacted) String() string   { return "<redacted>" }
+func (redacted) GoString() string { return "<redacted>" }
+
+// Format implements fmt.Formatter: the TIN is masked with the style set
+// by SetMaskStyle, and the birth dates and sex print as <redacted>. Use
+// Reveal for unmasked output.
+func (r Result) Format(f fmt.State, verb rune) {
+	r.format(f, verb, currentMaskStyle())
+}
+
+func (r Result) format(f fmt.State, verb rune, style MaskStyle) {
+	var v any = plainResult(r)
+	if style != MaskNone {
+		m := maskedResult{
+			TIN:                Mask(r.TIN, style),
+			ChecksumOK:         r.ChecksumOK,
+			BirthDatePlausible: r.BirthDatePlausible,
+			DOBMatched:         r.DOBMatched,
+			Valid:              r.Valid,
+		}
+		m.ProvidedDOB = r.ProvidedDOB
+		if r.ProvidedDOB != nil {
+			m.ProvidedDOB = redacted{}
+		}
+		v = m
+	}
+	s := fmt.Sprintf(fmt.FormatString(f, verb), v)
+	if f.Flag('#') {
+		s = strings.NewReplacer("uatins.plainResult", "uatins.Result", "uatins.maskedResult", "uatins.Result").Replace(s)
+	}
+	io.WriteString(f, s)
+}
+
+// LogValue implements slog.LogValuer. The TIN is masked and the decoded
+// birth date is left out unless masking is off.
+func (r Result) LogValue() slog.Value {
+	return r.logValue(currentMaskStyle())
+}
+
+func (r Result) logValue(style MaskStyle) slog.Value {
+	attrs := []slog.Attr{
+		slog.String("tin", Mask(r.TIN, style)),
+		slog.Bool("valid", r.Valid),
+		slog.Bool("checksum_ok", r.ChecksumOK),
+		slog.Bool("dob_matched", r.DOBMatched),
+	}
+	if style == MaskNone && !r.BirthDate.IsZero() {
+		attrs = append(attrs, slog.String("birth_date", r.BirthDate.Format(dateLayout)), slog.String("sex", string(r.Sex)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Format implements fmt.Formatter. %v and %s print the message with any
+// occurrence of the TIN masked; %+v adds the code, masked TIN and rule.
+// Use Reveal for unmasked output.
+func (e *Error) Format(f fmt.State, verb rune) {
+	e.format(f, verb, currentMaskStyle())
+}
+
+// message returns e.Error() with the TIN masked.
+func (e *Error) message(style MaskStyle) string {
+	msg := e.Error()
+	if e.TIN != "" && style != MaskNone {
+		msg = strings.ReplaceAll(msg, e.TIN, Mask(e.TIN, style))
+	}
+	return msg
+}
+
+func (e *Error) format(f fmt.State, verb rune, style MaskStyle) {
+	if e == nil {
+		io.WriteString(f, "<nil>")
+		return
+	}
+	msg := e.message(style)
+	switch verb {
+	case 'v':
+		if f.Flag('+') || f.Flag('#') {
+			fmt.Fprintf(f, "%s (code=%s", msg, e.Code)
+			if e.TIN != "" {
+				fmt.Fprintf(f, " tin=%s", Mask(e.TIN, style))
+			}
+			if e.Rule != "" {
+				fmt.Fprintf(f, " rule=%s", e.Rule)
+			}
+			io.WriteString(f, ")")
+			return
+		}
+		io.WriteString(f, msg)
+	case 's':
+		io.WriteString(f, msg)
+	case 'q':
+		fmt.Fprintf(f, "%q", msg)
+	default:
+		fmt.Fprintf(f, "%%!%c(*uatins.Error=%s)", verb, msg)
+	}
+}
+
+// LogValue implements slog.LogValuer with the TIN masked.
+func (e *Error) LogValue() slog.Value {
+	return e.logValue(currentMaskStyle())
+}
+
+func (e *Error) logValue(style MaskStyle) slog.Value {
+	if e == nil {
+		return slog.StringValue("<nil>")
+	}
+	attrs := []slog.Attr{
+		slog.String("code", string(e.Code)),
+		slog.String("msg", e.message(style)),
+	}
+	if e.TIN != "" {
+		attrs = append(attrs, slog.String("tin", Mask(e.TIN, style)))
+	}
+	if e.Rule != "" {
+		attrs = append(attrs, slog.String("rule", e.Rule))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Format implements fmt.Formatter, formatting every entry like %v does
+// for a single error so TINs stay masked.
+func (e ValidationErrors) Format(f fmt.State, verb rune) {
+	e.format(f, verb, currentMaskStyle())
+}
+
+func (e ValidationErrors) format(f fmt.State, verb rune, style MaskStyle) {
+	for i, err := range e {
+		if i > 0 {
+			io.WriteString(f, "\n")
+		}
+		if m, ok := err.(maskFormatter); ok {
+			m.format(f, verb, style)
+		} else {
+			fmt.Fprintf(f, fmt.FormatString(f, verb), err)
+		}
+	}
+}
+
+// LogValue implements slog.LogValuer as a list of masked errors.
+func (e ValidationErrors) LogValue() slog.Value {
+	return e.logValue(currentMaskStyle())
+}
+
+func (e ValidationErrors) logValue(style MaskStyle) slog.Value {
+	attrs := make([]slog.Attr, len(e))
+	for i, err := range e {
+		v := slog.StringValue(err.Error())
+		if m, ok := err.(maskFormatter); ok {
+			v = m.logValue(style)
+		}
+		attrs[i] = slog.Attr{Key: fmt.Sprint(i), Value: v}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Format implements fmt.Formatter, masking the TIN in the wrapped error.
+func (e *FieldError) Format(f fmt.State, verb rune) {
+	e.format(f, verb, currentMaskStyle())
+}
+
+func (e *FieldError) format(f fmt.State, verb rune, style MaskStyle) {
+	io.WriteString(f, e.Field+": ")
+	if m, ok := e.Err.(maskFormatter); ok {
+		m.format(f, verb, style)
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), e.Err)
+}
+
+// LogValue implements slog.LogValuer with the TIN masked.
+func (e *FieldError) LogValue() slog.Value {
+	return e.logValue(currentMaskStyle())
+}
+
+func (e *FieldError) logValue(style MaskStyle) slog.Value {
+	v := slog.StringValue(e.Err.Error())
+	if m, ok := e.Err.(maskFormatter); ok {
+		v = m.logValue(style)
+	}
+	return slog.GroupValue(slog.String("field", e.Field), slog.Attr{Key: "error", Value: v})
+}
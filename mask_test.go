@@ -0,0 +1,144 @@
+package uatins
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		tin   string
+		style MaskStyle
+		want  string
+	}{
+		{"3036045681", MaskEdges, "30******81"},
+		{"3036045681", MaskKeepBirth, "30360*****"},
+		{"3036045681", MaskFull, "**********"},
+		{"3036045681", MaskNone, "3036045681"},
+		{"1234", MaskEdges, "****"},
+		{"12345", MaskKeepBirth, "*****"},
+		{"", MaskFull, ""},
+	}
+	for _, tt := range tests {
+		if got := Mask(tt.tin, tt.style); got != tt.want {
+			t.Errorf("Mask(%q, %d) = %q, want %q", tt.tin, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestResult_Format(t *testing.T) {
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	res, _ := NewClient().Validate("3036045681", &dob)
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		got := fmt.Sprintf(format, res)
+		if strings.Contains(got, "3036045681") || !strings.Contains(got, "30******81") {
+			t.Errorf("%s: TIN not masked: %s", format, got)
+		}
+		if strings.Contains(got, "1983") || strings.Contains(got, "female") {
+			t.Errorf("%s: birth date or sex leaked: %s", format, got)
+		}
+	}
+	if got := fmt.Sprintf("%#v", res); !strings.HasPrefix(got, "uatins.Result{") {
+		t.Errorf("unexpected Go syntax %s", got)
+	}
+	if got := fmt.Sprintf("%+v", res); !strings.Contains(got, "BirthDate:<redacted>") || !strings.Contains(got, "ProvidedDOB:<redacted>") || strings.Contains(got, "0001") {
+		t.Errorf("redacted fields must print as a placeholder: %s", got)
+	}
+	res.ProvidedDOB = nil
+	if got := fmt.Sprintf("%v", res); !strings.HasSuffix(got, " <nil>}") {
+		t.Errorf("a nil ProvidedDOB hides nothing: %s", got)
+	}
+	if got := fmt.Sprintf("%v", Reveal(res)); !strings.Contains(got, "3036045681") || !strings.Contains(got, "1983-02-14") {
+		t.Errorf("Reveal must not mask: %s", got)
+	}
+}
+
+func TestError_Format(t *testing.T) {
+	e := &Error{Code: "BLOCKED", TIN: "3036045681", Msg: "tin 3036045681 is blocked", Rule: "blocklist"}
+
+	if got := fmt.Sprintf("%v", e); got != "tin 30******81 is blocked" {
+		t.Errorf("%%v = %q", got)
+	}
+	if got := fmt.Sprintf("%+v", e); got != "tin 30******81 is blocked (code=BLOCKED tin=30******81 rule=blocklist)" {
+		t.Errorf("%%+v = %q", got)
+	}
+	if got := fmt.Sprintf("%q", e); got != `"tin 30******81 is blocked"` {
+		t.Errorf("%%q = %q", got)
+	}
+	if got := fmt.Sprintf("%v", Reveal(e)); got != e.Error() {
+		t.Errorf("Reveal = %q", got)
+	}
+	// Error() itself is unchanged.
+	if e.Error() != "tin 3036045681 is blocked" {
+		t.Errorf("Error() = %q", e.Error())
+	}
+
+	errs := ValidationErrors{e, &FieldError{Field: "Staff[0].TIN", Err: e}}
+	if got := fmt.Sprintf("%v", errs); got != "tin 30******81 is blocked\nStaff[0].TIN: tin 30******81 is blocked" {
+		t.Errorf("ValidationErrors %%v = %q", got)
+	}
+	// fmt.Errorf formats %w operands like %v, so wrapping keeps the mask.
+	if got := fmt.Errorf("wrapped: %w", e).Error(); got != "wrapped: tin 30******81 is blocked" {
+		t.Errorf("%%w = %q", got)
+	}
+}
+
+func TestError_FormatCustomRule(t *testing.T) {
+	client := NewClient(WithRule(NamedRule{
+		Name:  "blocklist",
+		Stage: StageDecoded,
+		Check: func(s string) error { return fmt.Errorf("blocked %s", s) },
+	}))
+	_, err := client.Validate("3036045681", nil)
+	if got := fmt.Sprintf("%v", err); got != "blocked 30******81" {
+		t.Fatalf("custom rule error not masked: %q", got)
+	}
+}
+
+func TestMask_Slog(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	dob := time.Date(1983, 2, 15, 0, 0, 0, 0, time.UTC)
+	res, err := NewClient(WithStrict(true)).Validate("3036045681", &dob)
+	log.Info("checked", "result", res, "err", err)
+	got := buf.String()
+	if strings.Contains(got, "3036045681") || strings.Contains(got, "1983-02-14") {
+		t.Fatalf("log leaks personal data: %s", got)
+	}
+	if !strings.Contains(got, "result.tin=30******81") || !strings.Contains(got, "err.code=DOB_MISMATCH") {
+		t.Fatalf("unexpected log line: %s", got)
+	}
+
+	buf.Reset()
+	log.Info("checked", "err", (*Error)(nil))
+	if !strings.Contains(buf.String(), "err=<nil>") {
+		t.Fatalf("nil *Error not logged: %s", buf.String())
+	}
+
+	buf.Reset()
+	log.Info("checked", "result", Reveal(res))
+	if !strings.Contains(buf.String(), "result.tin=3036045681") || !strings.Contains(buf.String(), "result.birth_date=1983-02-14") {
+		t.Fatalf("Reveal must log the full result: %s", buf.String())
+	}
+}
+
+func TestSetMaskStyle(t *testing.T) {
+	defer SetMaskStyle(MaskEdges)
+	SetMaskStyle(MaskKeepBirth)
+	res, _ := NewClient().Validate("3036045681", nil)
+	if got := fmt.Sprintf("%v", res); !strings.Contains(got, "30360*****") {
+		t.Fatalf("style not applied: %s", got)
+	}
+}
@@ -0,0 +1,290 @@
+// Package middleware validates TIN fields of incoming HTTP requests.
+//
+// A Middleware extracts the TIN, and optionally a birth date, from a JSON
+// body, form values or the query string, validates them with a configured
+// uatins.Client and either stores the Result in the request context or
+// answers 422 with an RFC 9457 application/problem+json body:
+//
+//	mw := middleware.New(uatins.NewClient(uatins.WithStrict(true)),
+//		middleware.WithTINFields("rnokpp"),
+//		middleware.WithDOBFields("birth_date"),
+//	)
+//	http.Handle("POST /signup", mw.Handler(signup))
+//
+//	func signup(w http.ResponseWriter, r *http.Request) {
+//		res, _ := middleware.ResultFromContext(r.Context())
+//		...
+//	}
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// CodeMissingTIN is reported when none of the TIN fields is present. A
+// birth date in an unknown layout is reported as uatins.CodeDOBFormat and a
+// body that cannot be parsed as uatins.CodeBadRequest.
+const CodeMissingTIN uatins.ErrorCode = "TIN_REQUIRED"
+
+// ErrMissingTIN is the sentinel for CodeMissingTIN.
+var ErrMissingTIN = errors.New("tin is required")
+
+func init() {
+	uatins.MustRegisterCode(CodeMissingTIN, ErrMissingTIN)
+}
+
+// Middleware extracts and validates TIN fields. It is immutable once built
+// and safe for concurrent use.
+type Middleware struct {
+	client    uatins.Validator
+	tinFields []string
+	dobFields []string
+	layouts   []string
+	optional  bool
+	maxBody   int64
+	onError   func(http.ResponseWriter, *http.Request, error)
+}
+
+// Option configures a Middleware.
+type Option func(*Middleware)
+
+// New returns a Middleware validating with client, which may be a
+// *uatins.Client or any decorated Validator, or with a default client if
+// client is nil. By default it reads the TIN from the fields "tin" or
+// "rnokpp", the birth date from "dob" or "birth_date" (YYYY-MM-DD or
+// DD.MM.YYYY), requires the TIN, and reads at most 1 MiB of a JSON body.
+func New(client uatins.Validator, opts ...Option) *Middleware {
+	if client == nil {
+		client = uatins.NewClient()
+	}
+	m := &Middleware{
+		client:    client,
+		tinFields: []string{"tin", "rnokpp"},
+		dobFields: []string{"dob", "birth_date"},
+		layouts:   []string{"2006-01-02", "02.01.2006"},
+		maxBody:   1 << 20,
+		onError:   WriteProblem,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// WithTINFields sets the field names searched, in order, for the TIN.
+func WithTINFields(names ...string) Option {
+	return func(m *Middleware) {
+		if len(names) > 0 {
+			m.tinFields = slices.Clone(names)
+		}
+	}
+}
+
+// WithDOBFields sets the field names searched, in order, for the birth
+// date. No names disables the DOB cross-check.
+func WithDOBFields(names ...string) Option {
+	return func(m *Middleware) {
+		m.dobFields = slices.Clone(names)
+	}
+}
+
+// WithDateLayouts sets the time.Parse layouts tried, in order, for the
+// birth date.
+func WithDateLayouts(layouts ...string) Option {
+	return func(m *Middleware) {
+		if len(layouts) > 0 {
+			m.layouts = slices.Clone(layouts)
+		}
+	}
+}
+
+// WithOptional lets requests without a TIN field through unvalidated;
+// ResultFromContext then reports false.
+func WithOptional(on bool) Option {
+	return func(m *Middleware) {
+		m.optional = on
+	}
+}
+
+// WithMaxBodyBytes limits how much of a JSON body is read.
+func WithMaxBodyBytes(n int64) Option {
+	return func(m *Middleware) {
+		m.maxBody = n
+	}
+}
+
+// WithErrorHandler replaces WriteProblem as the response written when
+// extraction or validation fails.
+func WithErrorHandler(h func(http.ResponseWriter, *http.Request, error)) Option {
+	return func(m *Middleware) {
+		if h != nil {
+			m.onError = h
+		}
+	}
+}
+
+type resultKey struct{}
+
+// NewContext returns a copy of ctx carrying res.
+func NewContext(ctx context.Context, res uatins.Result) context.Context {
+	return context.WithValue(ctx, resultKey{}, res)
+}
+
+// ResultFromContext returns the Result stored by Handler, if any.
+func ResultFromContext(ctx context.Context) (uatins.Result, bool) {
+	res, ok := ctx.Value(resultKey{}).(uatins.Result)
+	return res, ok
+}
+
+// Handler validates the TIN of every request before calling next with the
+// Result in the request context. Failing requests are answered by the
+// error handler and never reach next.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, err := m.Validate(r)
+		switch {
+		case errors.Is(err, ErrMissingTIN) && m.optional:
+			next.ServeHTTP(w, r)
+		case err != nil:
+			m.onError(w, r, err)
+		default:
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), res)))
+		}
+	})
+}
+
+// Validate extracts the fields from r and validates them. A result that is
+// not Valid is always reported with an error. Use it directly in handlers
+// that do not want the middleware.
+func (m *Middleware) Validate(r *http.Request) (uatins.Result, error) {
+	tin, dob, err := m.Extract(r)
+	if err != nil {
+		return uatins.Result{}, err
+	}
+	res, err := m.client.ValidateContext(r.Context(), tin, dob)
+	if err == nil && !res.Valid {
+		err = uatins.VerifyChecksum(res.TIN)
+	}
+	return res, err
+}
+
+// Extract returns the TIN and optional birth date of r. A JSON body is
+// searched first, then form values, then the query string; the body is
+// restored so later handlers can read it again.
+func (m *Middleware) Extract(r *http.Request) (string, *time.Time, error) {
+	lookup, err := m.fields(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tin, _, ok := first(lookup, m.tinFields)
+	if !ok {
+		return "", nil, &uatins.Error{
+			Code:   CodeMissingTIN,
+			Msg:    fmt.Sprintf("one of the fields %s is required", strings.Join(m.tinFields, ", ")),
+			Params: map[string]string{"field": m.tinFields[0]},
+		}
+	}
+
+	s, field, ok := first(lookup, m.dobFields)
+	if !ok {
+		return tin, nil, nil
+	}
+	for _, layout := range m.layouts {
+		if d, err := time.Parse(layout, s); err == nil {
+			return tin, &d, nil
+		}
+	}
+	return tin, nil, &uatins.Error{
+		Code:   uatins.CodeDOBFormat,
+		TIN:    tin,
+		Msg:    fmt.Sprintf("field %s: cannot parse birth date %q", field, s),
+		Params: map[string]string{"field": field},
+	}
+}
+
+// first returns the first non-empty value among names.
+func first(lookup func(string) string, names []string) (value, field string, ok bool) {
+	for _, name := range names {
+		if v := strings.TrimSpace(lookup(name)); v != "" {
+			return v, name, true
+		}
+	}
+	return "", "", false
+}
+
+// fields returns a lookup over the request's JSON body, form or query.
+func (m *Middleware) fields(r *http.Request) (func(string) string, error) {
+	query := r.URL.Query()
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	if ct == "application/json" || strings.HasSuffix(ct, "+json") {
+		body, err := io.ReadAll(io.LimitReader(r.Body, m.maxBody+1))
+		if err != nil {
+			return nil, badRequest("cannot read body: " + err.Error())
+		}
+		if int64(len(body)) > m.maxBody {
+			return nil, badRequest("request body too large")
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var obj map[string]json.RawMessage
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := json.Unmarshal(body, &obj); err != nil {
+				return nil, badRequest("invalid JSON body: " + err.Error())
+			}
+		}
+		return func(name string) string {
+			if v := jsonString(obj[name]); v != "" {
+				return v
+			}
+			return query.Get(name)
+		}, nil
+	}
+
+	var err error
+	if ct == "multipart/form-data" {
+		err = r.ParseMultipartForm(m.maxBody)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return nil, badRequest("invalid form: " + err.Error())
+	}
+	return func(name string) string {
+		if v := r.PostForm.Get(name); v != "" {
+			return v
+		}
+		return query.Get(name)
+	}, nil
+}
+
+// jsonString returns a JSON string or number as text, so {"tin":3036045681}
+// is accepted like {"tin":"3036045681"}.
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
+
+func badRequest(msg string) error {
+	return &uatins.Error{Code: uatins.CodeBadRequest, Msg: msg}
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// echo reports the Result from the context and the body it received.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	res, ok := ResultFromContext(r.Context())
+	body, _ := io.ReadAll(r.Body)
+	json.NewEncoder(w).Encode(map[string]any{"ok": ok, "result": res, "body": string(body)})
+})
+
+func serve(h http.Handler, method, target, contentType, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestHandler_Sources(t *testing.T) {
+	h := New(uatins.NewClient(uatins.WithStrict(true))).Handler(echo)
+
+	tests := []struct {
+		name, method, target, ct, body string
+	}{
+		{"json", "POST", "/", "application/json", `{"tin":"3036045681","dob":"1983-02-14"}`},
+		{"json number", "POST", "/", "application/merge-patch+json", `{"rnokpp":3036045681}`},
+		{"form", "POST", "/", "application/x-www-form-urlencoded", "rnokpp=3036045681&birth_date=14.02.1983"},
+		{"query", "GET", "/?tin=3036045681", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(h, tt.method, tt.target, tt.ct, tt.body)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status %d: %s", rec.Code, rec.Body)
+			}
+			var out struct {
+				OK     bool          `json:"ok"`
+				Result uatins.Result `json:"result"`
+				Body   string        `json:"body"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+				t.Fatal(err)
+			}
+			if !out.OK || !out.Result.Valid || out.Result.Sex != uatins.Female {
+				t.Fatalf("unexpected result %+v", out)
+			}
+			if tt.ct != "" && strings.HasSuffix(tt.ct, "json") && out.Body != tt.body {
+				t.Fatalf("JSON body not restored: %q", out.Body)
+			}
+		})
+	}
+}
+
+func TestHandler_Problem(t *testing.T) {
+	h := New(uatins.NewClient(uatins.WithStrict(true), uatins.WithLanguage(uatins.LangUkrainian)),
+		WithTINFields("inn"), WithDOBFields("born")).Handler(echo)
+
+	tests := []struct {
+		name, target string
+		status       int
+		code         uatins.ErrorCode
+	}{
+		{"missing", "/?tin=3036045681", http.StatusUnprocessableEntity, CodeMissingTIN},
+		{"length", "/?inn=12", http.StatusUnprocessableEntity, uatins.CodeLength},
+		{"checksum", "/?inn=3036045687", http.StatusUnprocessableEntity, uatins.CodeChecksum},
+		{"mismatch", "/?inn=3036045681&born=1983-02-15", http.StatusUnprocessableEntity, uatins.CodeDOBMismatch},
+		{"date", "/?inn=3036045681&born=yesterday", http.StatusUnprocessableEntity, uatins.CodeDOBFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(h, "GET", tt.target, "", "")
+			if rec.Code != tt.status || rec.Header().Get("Content-Type") != ProblemContentType {
+				t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+			}
+			var p Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatal(err)
+			}
+			if p.Status != tt.status || p.Code != tt.code || p.Type != "about:blank" || p.Instance != "/" || len(p.Errors) != 1 {
+				t.Fatalf("unexpected problem %+v", p)
+			}
+		})
+	}
+
+	rec := serve(h, "GET", "/?inn=12", "", "")
+	if !strings.Contains(rec.Body.String(), "РНОКПП має містити 10 цифр") {
+		t.Errorf("expected the client's language in the detail: %s", rec.Body)
+	}
+
+	rec = serve(h, "POST", "/", "application/json", `{"inn":`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("malformed JSON: status %d", rec.Code)
+	}
+}
+
+func TestHandler_Optional(t *testing.T) {
+	h := New(nil, WithOptional(true)).Handler(echo)
+
+	rec := serve(h, "GET", "/", "", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ok":false`) {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	if rec := serve(h, "GET", "/?tin=12", "", ""); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("a present but invalid TIN must still be rejected, got %d", rec.Code)
+	}
+}
+
+func TestHandler_ErrorHandler(t *testing.T) {
+	var got error
+	h := New(nil, WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+		got = err
+		w.WriteHeader(http.StatusTeapot)
+	})).Handler(echo)
+
+	form := url.Values{"tin": {"3036045687"}}
+	rec := serve(h, "POST", "/", "application/x-www-form-urlencoded", form.Encode())
+	if rec.Code != http.StatusTeapot || uatins.CodeOf(got) != uatins.CodeChecksum {
+		t.Fatalf("status %d, err %v", rec.Code, got)
+	}
+}
+
+func TestNewProblem_AllErrors(t *testing.T) {
+	_, err := uatins.NewClient(uatins.WithAllErrors(true)).Validate("3036045687", nil)
+	p := NewProblem(err)
+	if p.Status != http.StatusUnprocessableEntity || p.Code != uatins.CodeOf(err) || len(p.Errors) == 0 {
+		t.Fatalf("unexpected problem %+v", p)
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details object. Code and Errors are
+// extension members carrying the machine-readable uatins error codes.
+type Problem struct {
+	Type     string           `json:"type"`
+	Title    string           `json:"title"`
+	Status   int              `json:"status"`
+	Detail   string           `json:"detail,omitempty"`
+	Instance string           `json:"instance,omitempty"`
+	Code     uatins.ErrorCode `json:"code,omitempty"`
+	Errors   []*uatins.Error  `json:"errors,omitempty"`
+}
+
+// NewProblem describes err. Request errors such as an unparsable body get
+// status 400; everything else, including a missing or invalid TIN, 422.
+func NewProblem(err error) *Problem {
+	status := http.StatusUnprocessableEntity
+	if uatins.CodeOf(err) == uatins.CodeBadRequest {
+		status = http.StatusBadRequest
+	}
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+		Code:   uatins.CodeOf(err),
+	}
+
+	var errs uatins.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			p.Errors = append(p.Errors, asError(e))
+		}
+		if len(errs) > 0 {
+			p.Code = uatins.CodeOf(errs[0])
+			p.Detail = errs[0].Error()
+		}
+	} else {
+		p.Errors = []*uatins.Error{asError(err)}
+	}
+	return p
+}
+
+// asError returns err as an *uatins.Error.
+func asError(err error) *uatins.Error {
+	var e *uatins.Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &uatins.Error{Code: uatins.CodeOf(err), Msg: err.Error()}
+}
+
+// WriteProblem answers r with the problem details of err. It is the
+// default error handler of a Middleware.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := NewProblem(err)
+	p.Instance = r.URL.Path
+	p.Write(w)
+}
+
+// Write sends p with its status and the problem+json content type.
+func (p *Problem) Write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
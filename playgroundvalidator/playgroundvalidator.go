@@ -0,0 +1,102 @@
+// Package playgroundvalidator registers uatins checks as
+// github.com/go-playground/validator/v10 tags, so TIN fields are validated
+// in the same pass as the rest of a struct:
+//
+//	type Employee struct {
+//		TIN       string    `validate:"required,rnokpp_dob=BirthDate,rnokpp_sex=Gender"`
+//		BirthDate time.Time
+//		Gender    string
+//	}
+//
+//	v := validator.New()
+//	if err := playgroundvalidator.Register(v, nil); err != nil { ... }
+//
+// It lives in its own module so that the uatins module stays free of
+// dependencies.
+package playgroundvalidator
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stremovskyy/uatins"
+)
+
+// Tags registered by Register.
+const (
+	// TagRNOKPP accepts a valid TIN.
+	TagRNOKPP = "rnokpp"
+	// TagRNOKPPDOB accepts a valid TIN whose encoded birth date equals the
+	// time.Time field named by the parameter, e.g. `rnokpp_dob=BirthDate`.
+	// The check is always strict; a zero or nil date only checks the TIN.
+	TagRNOKPPDOB = "rnokpp_dob"
+	// TagRNOKPPSex accepts a valid TIN whose encoded sex equals the field
+	// named by the parameter ("male" or "female"), e.g. `rnokpp_sex=Gender`.
+	// An empty sex only checks the TIN.
+	TagRNOKPPSex = "rnokpp_sex"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// Register adds the rnokpp tags to v, validating with client or with a
+// default client if client is nil.
+func Register(v *validator.Validate, client *uatins.Client) error {
+	if client == nil {
+		client = uatins.NewClient()
+	}
+	strict := client.Strict(true)
+
+	funcs := map[string]validator.Func{
+		TagRNOKPP: func(fl validator.FieldLevel) bool {
+			return valid(client, fl.Field().String(), nil)
+		},
+		TagRNOKPPDOB: func(fl validator.FieldLevel) bool {
+			return valid(strict, fl.Field().String(), dobParam(fl))
+		},
+		TagRNOKPPSex: func(fl validator.FieldLevel) bool {
+			res, err := client.Validate(fl.Field().String(), nil)
+			if err != nil || !res.Valid {
+				return false
+			}
+			want, ok := sexParam(fl)
+			return !ok || want == "" || want == res.Sex
+		},
+	}
+	for tag, fn := range funcs {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// valid reports whether tin passes client, cross-checked against dob.
+func valid(client *uatins.Client, tin string, dob *time.Time) bool {
+	res, err := client.Validate(tin, dob)
+	return err == nil && res.Valid
+}
+
+// dobParam returns the birth date held by the sibling field named in the
+// tag parameter, or nil if it is missing or unset.
+func dobParam(fl validator.FieldLevel) *time.Time {
+	field, _, _, found := fl.GetStructFieldOK2()
+	if !found || field.Type() != timeType {
+		return nil
+	}
+	t := field.Interface().(time.Time)
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// sexParam returns the sex held by the sibling field named in the tag
+// parameter.
+func sexParam(fl validator.FieldLevel) (uatins.Sex, bool) {
+	field, kind, _, found := fl.GetStructFieldOK2()
+	if !found || kind != reflect.String {
+		return "", false
+	}
+	return uatins.Sex(field.String()), true
+}
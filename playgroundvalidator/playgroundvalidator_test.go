@@ -0,0 +1,89 @@
+package playgroundvalidator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/locales/uk"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/stremovskyy/uatins"
+)
+
+type employee struct {
+	TIN       string  `validate:"required,rnokpp_dob=BirthDate,rnokpp_sex=Gender"`
+	Spouse    *string `validate:"omitempty,rnokpp"`
+	BirthDate time.Time
+	Gender    string
+}
+
+func newValidator(t *testing.T) *validator.Validate {
+	t.Helper()
+	v := validator.New()
+	if err := Register(v, uatins.NewClient()); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestRegister(t *testing.T) {
+	v := newValidator(t)
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	bad := "3036045687"
+
+	tests := []struct {
+		name string
+		e    employee
+		tags []string
+	}{
+		{"valid", employee{TIN: "3036045681", BirthDate: dob, Gender: "female"}, nil},
+		{"no cross-check", employee{TIN: "3036045681"}, nil},
+		{"checksum", employee{TIN: "3036045687"}, []string{TagRNOKPPDOB}},
+		{"dob mismatch", employee{TIN: "3036045681", BirthDate: dob.AddDate(0, 0, 1)}, []string{TagRNOKPPDOB}},
+		{"sex mismatch", employee{TIN: "3036045681", Gender: "male"}, []string{TagRNOKPPSex}},
+		{"spouse", employee{TIN: "3036045681", Spouse: &bad}, []string{TagRNOKPP}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.e)
+			var verrs validator.ValidationErrors
+			if len(tt.tags) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &verrs) || len(verrs) != len(tt.tags) {
+				t.Fatalf("expected %v, got %v", tt.tags, err)
+			}
+			for i, fe := range verrs {
+				if fe.Tag() != tt.tags[i] {
+					t.Errorf("tag %q, want %q", fe.Tag(), tt.tags[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterTranslations(t *testing.T) {
+	v := newValidator(t)
+	trans, _ := ut.New(uk.New()).GetTranslator("uk")
+	if err := RegisterTranslations(v, trans, uatins.LangUkrainian); err != nil {
+		t.Fatal(err)
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(v.Struct(employee{TIN: "12"}), &verrs) {
+		t.Fatal("expected validation errors")
+	}
+	got := verrs.Translate(trans)["employee.TIN"]
+	want := "TIN має бути дійсним РНОКПП, що відповідає даті народження в полі BirthDate"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if err := RegisterTranslations(v, trans, "de"); err == nil {
+		t.Fatal("expected an error for an unknown language")
+	}
+}
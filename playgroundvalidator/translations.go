@@ -0,0 +1,49 @@
+package playgroundvalidator
+
+import (
+	"fmt"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/stremovskyy/uatins"
+)
+
+// messages holds the translations of each tag; {0} is the field name and
+// {1} the tag parameter.
+var messages = map[string]map[string]string{
+	uatins.LangEnglish: {
+		TagRNOKPP:    "{0} must be a valid RNOKPP",
+		TagRNOKPPDOB: "{0} must be a valid RNOKPP matching the birth date in {1}",
+		TagRNOKPPSex: "{0} must be a valid RNOKPP matching the sex in {1}",
+	},
+	uatins.LangUkrainian: {
+		TagRNOKPP:    "{0} має бути дійсним РНОКПП",
+		TagRNOKPPDOB: "{0} має бути дійсним РНОКПП, що відповідає даті народження в полі {1}",
+		TagRNOKPPSex: "{0} має бути дійсним РНОКПП, що відповідає статі в полі {1}",
+	},
+}
+
+// RegisterTranslations adds the messages of the rnokpp tags in lang
+// ("en" or "uk") to trans, for use with validator.ValidationErrors.Translate.
+func RegisterTranslations(v *validator.Validate, trans ut.Translator, lang string) error {
+	msgs, ok := messages[lang]
+	if !ok {
+		return fmt.Errorf("playgroundvalidator: no translations for %q", lang)
+	}
+	for tag, text := range msgs {
+		register := func(t ut.Translator) error {
+			return t.Add(tag, text, true)
+		}
+		translate := func(t ut.Translator, fe validator.FieldError) string {
+			s, err := t.T(fe.Tag(), fe.Field(), fe.Param())
+			if err != nil {
+				return fe.Error()
+			}
+			return s
+		}
+		if err := v.RegisterTranslation(tag, trans, register, translate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Package pseudonym replaces TINs with keyed, joinable stand-ins so data
+// sets can be linked without storing the raw numbers.
+//
+// Pseudonymize produces an HMAC-SHA256 pseudonym prefixed with the ID of
+// the key that made it ("k2.Wm9v..."). It cannot be reversed; the same TIN
+// and key always give the same pseudonym, so columns can be joined.
+//
+// Tokenize produces a format-preserving 10-digit token instead. The first
+// nine digits are a keyed permutation of the TIN's first nine digits; the
+// last digit is the token's check digit offset by the key slot (1..9), so
+// a token never passes uatins.ChecksumOK and cannot be mistaken for a real
+// TIN. Detokenize reverses it for holders of the key.
+//
+// Keys rotate by passing the new key first and older keys after it: new
+// values use the first key, while Match and Detokenize accept all.
+package pseudonym
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// MinSecretLen is the minimum length of a key secret in bytes.
+const MinSecretLen = 16
+
+// Errors returned for tokens and pseudonyms that cannot be used.
+var (
+	ErrMalformed  = errors.New("pseudonym: malformed token")
+	ErrUnknownKey = errors.New("pseudonym: unknown key")
+	ErrNotToken   = errors.New("pseudonym: value is a real TIN, not a token")
+)
+
+// Key is one secret of a Pseudonymizer.
+type Key struct {
+	// ID names the key inside pseudonyms. It must be non-empty and must
+	// not contain '.'.
+	ID string
+	// Slot (1..9) identifies the key inside tokens. It is only required
+	// for Tokenize; 0 means the key cannot tokenize.
+	Slot int
+	// Secret is at least MinSecretLen random bytes.
+	Secret []byte
+}
+
+// key is a Key with its derived subkeys.
+type key struct {
+	Key
+	hmacKey []byte
+	fpeKey  []byte
+}
+
+// Pseudonymizer creates and checks pseudonyms and tokens. It is safe for
+// concurrent use.
+type Pseudonymizer struct {
+	keys []key // keys[0] is the current key
+}
+
+// New returns a Pseudonymizer that issues values with current and also
+// accepts values made with any of the older keys.
+func New(current Key, older ...Key) (*Pseudonymizer, error) {
+	p := &Pseudonymizer{}
+	ids := make(map[string]bool)
+	slots := make(map[int]bool)
+	for _, k := range append([]Key{current}, older...) {
+		switch {
+		case k.ID == "" || strings.Contains(k.ID, "."):
+			return nil, fmt.Errorf("pseudonym: invalid key ID %q", k.ID)
+		case ids[k.ID]:
+			return nil, fmt.Errorf("pseudonym: duplicate key ID %q", k.ID)
+		case k.Slot < 0 || k.Slot > 9:
+			return nil, fmt.Errorf("pseudonym: key %s: slot must be 1..9", k.ID)
+		case k.Slot != 0 && slots[k.Slot]:
+			return nil, fmt.Errorf("pseudonym: key %s: duplicate slot %d", k.ID, k.Slot)
+		case len(k.Secret) < MinSecretLen:
+			return nil, fmt.Errorf("pseudonym: key %s: secret must be at least %d bytes", k.ID, MinSecretLen)
+		}
+		ids[k.ID] = true
+		slots[k.Slot] = true
+		p.keys = append(p.keys, key{
+			Key:     k,
+			hmacKey: derive(k.Secret, "uatins pseudonym"),
+			fpeKey:  derive(k.Secret, "uatins token"),
+		})
+	}
+	return p, nil
+}
+
+// derive returns a subkey of secret for one purpose.
+func derive(secret []byte, purpose string) []byte {
+	m := hmac.New(sha256.New, secret)
+	m.Write([]byte(purpose))
+	return m.Sum(nil)
+}
+
+// normalize strips separators and checks that tin has 10 digits.
+func normalize(tin string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return 'x'
+	}, tin)
+	if strings.Contains(digits, "x") {
+		return "", fmt.Errorf("pseudonym: %w", uatins.ErrNonDigit)
+	}
+	if len(digits) != 10 {
+		return "", fmt.Errorf("pseudonym: %w", uatins.ErrLength)
+	}
+	return digits, nil
+}
+
+// Pseudonymize returns the pseudonym of tin under the current key:
+// the key ID, a dot and the unpadded base64url HMAC-SHA256 of the digits.
+// Separators (spaces, dashes) are ignored; the checksum is not required.
+func (p *Pseudonymizer) Pseudonymize(tin string) (string, error) {
+	digits, err := normalize(tin)
+	if err != nil {
+		return "", err
+	}
+	k := p.keys[0]
+	return k.ID + "." + base64.RawURLEncoding.EncodeToString(k.mac(digits)), nil
+}
+
+func (k key) mac(digits string) []byte {
+	m := hmac.New(sha256.New, k.hmacKey)
+	m.Write([]byte(digits))
+	return m.Sum(nil)
+}
+
+// KeyID returns the ID of the key that made pseudonym. Values made with an
+// older key can be found this way and re-issued with Pseudonymize.
+func (p *Pseudonymizer) KeyID(pseudonym string) (string, error) {
+	id, _, ok := strings.Cut(pseudonym, ".")
+	if !ok || id == "" {
+		return "", ErrMalformed
+	}
+	return id, nil
+}
+
+// Match reports whether pseudonym was made from tin with any known key.
+func (p *Pseudonymizer) Match(tin, pseudonym string) (bool, error) {
+	digits, err := normalize(tin)
+	if err != nil {
+		return false, err
+	}
+	id, enc, ok := strings.Cut(pseudonym, ".")
+	if !ok {
+		return false, ErrMalformed
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil || len(sum) != sha256.Size {
+		return false, ErrMalformed
+	}
+	for _, k := range p.keys {
+		if k.ID == id {
+			return hmac.Equal(sum, k.mac(digits)), nil
+		}
+	}
+	return false, fmt.Errorf("%w %q", ErrUnknownKey, id)
+}
+
+// Feistel parameters: the nine permuted digits are split 4 + 5.
+const (
+	rounds = 10
+	modL   = 10_000
+	modR   = 100_000
+)
+
+// Tokenize returns a 10-digit token for tin under the current key, which
+// must have a Slot. tin must have a valid checksum, since the token keeps
+// only its first nine digits.
+func (p *Pseudonymizer) Tokenize(tin string) (string, error) {
+	digits, err := normalize(tin)
+	if err != nil {
+		return "", err
+	}
+	if !uatins.ChecksumOK(digits) {
+		return "", fmt.Errorf("pseudonym: %w", uatins.ErrChecksum)
+	}
+	k := p.keys[0]
+	if k.Slot == 0 {
+		return "", fmt.Errorf("pseudonym: key %s has no slot for tokens", k.ID)
+	}
+
+	l, r := split(digits[:9])
+	for i := 0; i < rounds; i++ {
+		if i%2 == 0 {
+			l = (l + k.round(i, r, modL)) % modL
+		} else {
+			r = (r + k.round(i, l, modR)) % modR
+		}
+	}
+	prefix := fmt.Sprintf("%04d%05d", l, r)
+	cd, _ := uatins.CheckDigit(prefix)
+	return fmt.Sprintf("%s%d", prefix, (cd+k.Slot)%10), nil
+}
+
+// Detokenize returns the TIN behind token. It fails with ErrNotToken for a
+// number with a valid checksum and ErrUnknownKey if the token's key slot
+// is not held.
+func (p *Pseudonymizer) Detokenize(token string) (string, error) {
+	if len(token) != 10 || strings.Trim(token, "0123456789") != "" {
+		return "", ErrMalformed
+	}
+	cd, _ := uatins.CheckDigit(token[:9])
+	slot := (int(token[9]-'0') - cd + 10) % 10
+	if slot == 0 {
+		return "", ErrNotToken
+	}
+	var k *key
+	for i := range p.keys {
+		if p.keys[i].Slot == slot {
+			k = &p.keys[i]
+			break
+		}
+	}
+	if k == nil {
+		return "", fmt.Errorf("%w in slot %d", ErrUnknownKey, slot)
+	}
+
+	l, r := split(token[:9])
+	for i := rounds - 1; i >= 0; i-- {
+		if i%2 == 0 {
+			l = (l + modL - k.round(i, r, modL)) % modL
+		} else {
+			r = (r + modR - k.round(i, l, modR)) % modR
+		}
+	}
+	return uatins.AppendCheckDigit(fmt.Sprintf("%04d%05d", l, r))
+}
+
+// split parses nine digits into the 4- and 5-digit Feistel halves.
+func split(digits string) (l, r int) {
+	for _, c := range digits[:4] {
+		l = l*10 + int(c-'0')
+	}
+	for _, c := range digits[4:] {
+		r = r*10 + int(c-'0')
+	}
+	return l, r
+}
+
+// round is the Feistel round function: HMAC-SHA256 of the round number
+// and the other half, reduced modulo mod.
+func (k key) round(i, half, mod int) int {
+	var buf [9]byte
+	buf[0] = byte(i)
+	binary.BigEndian.PutUint64(buf[1:], uint64(half))
+	m := hmac.New(sha256.New, k.fpeKey)
+	m.Write(buf[:])
+	return int(binary.BigEndian.Uint64(m.Sum(nil)) % uint64(mod))
+}
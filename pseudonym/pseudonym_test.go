@@ -0,0 +1,128 @@
+package pseudonym
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/uatins"
+)
+
+var (
+	k1 = Key{ID: "k1", Slot: 1, Secret: []byte("0123456789abcdef-one")}
+	k2 = Key{ID: "k2", Slot: 2, Secret: []byte("0123456789abcdef-two")}
+)
+
+func mustNew(t *testing.T, current Key, older ...Key) *Pseudonymizer {
+	t.Helper()
+	p, err := New(current, older...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestNew(t *testing.T) {
+	bad := [][]Key{
+		{{ID: "", Secret: k1.Secret}},
+		{{ID: "a.b", Secret: k1.Secret}},
+		{{ID: "k", Secret: []byte("short")}},
+		{{ID: "k", Slot: 10, Secret: k1.Secret}},
+		{k1, {ID: "k1", Secret: k2.Secret}},
+		{k1, {ID: "k3", Slot: 1, Secret: k2.Secret}},
+	}
+	for _, keys := range bad {
+		if _, err := New(keys[0], keys[1:]...); err == nil {
+			t.Errorf("New(%+v): expected an error", keys)
+		}
+	}
+}
+
+func TestPseudonymize(t *testing.T) {
+	p := mustNew(t, k1)
+	a, err := p.Pseudonymize("3036045681")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := p.Pseudonymize("3036-045-681")
+	if a != b || !strings.HasPrefix(a, "k1.") || strings.Contains(a, "3036045681") {
+		t.Fatalf("unexpected pseudonyms %q, %q", a, b)
+	}
+	if c, _ := p.Pseudonymize("3036045682"); c == a {
+		t.Fatal("different TINs must not collide")
+	}
+	if _, err := p.Pseudonymize("12"); !errors.Is(err, uatins.ErrLength) {
+		t.Fatalf("expected ErrLength, got %v", err)
+	}
+
+	// After rotation new pseudonyms use k2, old ones still match.
+	rotated := mustNew(t, k2, k1)
+	if ok, err := rotated.Match("3036045681", a); !ok || err != nil {
+		t.Fatalf("Match(old) = %v, %v", ok, err)
+	}
+	fresh, _ := rotated.Pseudonymize("3036045681")
+	if id, _ := rotated.KeyID(fresh); id != "k2" || fresh == a {
+		t.Fatalf("expected a k2 pseudonym, got %q", fresh)
+	}
+	if ok, _ := rotated.Match("3036045682", a); ok {
+		t.Fatal("Match must fail for another TIN")
+	}
+	if _, err := p.Match("3036045681", fresh); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected ErrUnknownKey, got %v", err)
+	}
+	if _, err := p.Match("3036045681", "k1.!!"); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("expected ErrMalformed, got %v", err)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	p := mustNew(t, k1)
+	g := uatins.NewGenerator(uatins.WithSeed(1))
+	for i := 0; i < 200; i++ {
+		tin := g.MustGenerate(uatins.DaysToDate(10000+i*97), "")
+		tok, err := p.Tokenize(tin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tok) != 10 || uatins.ChecksumOK(tok) || tok == tin {
+			t.Fatalf("Tokenize(%s) = %s: must be 10 digits failing the checksum", tin, tok)
+		}
+		again, _ := p.Tokenize(tin)
+		if again != tok {
+			t.Fatalf("Tokenize is not deterministic: %s vs %s", tok, again)
+		}
+		back, err := p.Detokenize(tok)
+		if err != nil || back != tin {
+			t.Fatalf("Detokenize(%s) = %s, %v; want %s", tok, back, err, tin)
+		}
+	}
+
+	if _, err := p.Tokenize("3036045687"); !errors.Is(err, uatins.ErrChecksum) {
+		t.Fatalf("expected ErrChecksum, got %v", err)
+	}
+	if _, err := p.Detokenize("3036045681"); !errors.Is(err, ErrNotToken) {
+		t.Fatalf("expected ErrNotToken, got %v", err)
+	}
+	if _, err := p.Detokenize("30360456"); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("expected ErrMalformed, got %v", err)
+	}
+}
+
+func TestTokenize_Rotation(t *testing.T) {
+	old := mustNew(t, k1)
+	tok, _ := old.Tokenize("3036045681")
+
+	rotated := mustNew(t, k2, k1)
+	if back, err := rotated.Detokenize(tok); err != nil || back != "3036045681" {
+		t.Fatalf("Detokenize(old token) = %s, %v", back, err)
+	}
+	fresh, _ := rotated.Tokenize("3036045681")
+	if _, err := old.Detokenize(fresh); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected ErrUnknownKey, got %v", err)
+	}
+
+	noSlot := mustNew(t, Key{ID: "h", Secret: k1.Secret})
+	if _, err := noSlot.Tokenize("3036045681"); err == nil {
+		t.Fatal("expected an error for a key without a slot")
+	}
+}
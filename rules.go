@@ -0,0 +1,413 @@
+package uatins
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Stage identifies where a named rule runs inside Client.Validate.
+type Stage int
+
+const (
+	// StagePreNormalize rules see the raw input before non-digits are stripped.
+	StagePreNormalize Stage = iota
+	// StageStructural rules see the normalized digits before decoding.
+	StageStructural
+	// StageDecoded rules run once the birth date and sex are decoded.
+	StageDecoded
+	// StagePostChecksum rules run after the checksum has been computed.
+	StagePostChecksum
+
+	stageCount
+)
+
+func (s Stage) String() string {
+	switch s {
+	case StagePreNormalize:
+		return "pre-normalize"
+	case StageStructural:
+		return "structural"
+	case StageDecoded:
+		return "decoded"
+	case StagePostChecksum:
+		return "post-checksum"
+	default:
+		return "stage(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// Names of the built-in rules. Pass them to WithoutRules to disable a rule,
+// or register a NamedRule under the same name to replace it.
+const (
+	RuleAllDigits  = "all_digits"
+	RuleLength     = "length"
+	RuleNotAllSame = "not_all_same"
+	RulePlausible  = "birth_date_plausible"
+	RuleChecksum   = "checksum"
+	RuleDOBMatch   = "dob_match"
+)
+
+// NamedRule is a Rule registered under a name at a stage. Within a stage,
+// rules run by ascending Priority, then in registration order. Built-in
+// rules use priorities 100, 200, 300 and so on.
+//
+// Set exactly one of Check, which sees the TIN string, CheckResult, which
+// sees the Result decoded so far, or CheckContext, which also receives the
+// context passed to ValidateContext (for I/O-backed checks). CheckResult
+// and CheckContext rules run no earlier than StageDecoded; ChecksumOK and
+// DOBMatched are only filled in from StagePostChecksum on. Rules at
+// StageStructural and later only run on exactly 10 digits.
+//
+// A non-zero Timeout bounds a CheckContext rule; a rule that fails with
+// context.DeadlineExceeded is reported as ErrRuleTimeout. If the caller's
+// context ends first, ValidateContext returns its error instead.
+type NamedRule struct {
+	Name         string
+	Stage        Stage
+	Priority     int
+	Check        Rule[string]
+	CheckResult  Rule[Result]
+	CheckContext ContextRule[Result]
+	Timeout      time.Duration
+}
+
+// ContextRule is a validation rule that honours context cancellation.
+type ContextRule[T any] func(context.Context, T) error
+
+// step is one entry of a client's validation pipeline.
+type step struct {
+	NamedRule
+	builtin bool
+	run     func(*validation) error
+}
+
+// validation carries the state of a single Validate call through the pipeline.
+type validation struct {
+	ctx         context.Context
+	client      *Client
+	tin         string
+	providedDOB *time.Time
+	utcDOB      time.Time
+	res         Result
+	errs        ValidationErrors
+}
+
+// enter prepares the state that rules of the given stage rely on.
+func (v *validation) enter(stage Stage) error {
+	switch stage {
+	case StageStructural:
+		v.tin = digitsOnly(v.tin)
+		v.res.TIN = v.tin
+		v.res.ProvidedDOB = v.providedDOB
+
+	case StageDecoded:
+		if len(v.tin) != 10 {
+			if len(v.errs) > 0 {
+				return v.errs
+			}
+			// The length rule is disabled, but decoding still needs 10 digits.
+			err := wrapErr(ErrLength, v.tin, "need 10 digits", nil, nil)
+			err.Params = map[string]string{"length": "10"}
+			if v.client.allErrors {
+				return ValidationErrors{err}
+			}
+			return err
+		}
+
+		// Decode DOB from digits 1..5 and sex from digit 9.
+		ddays, _ := strconv.Atoi(v.tin[:5])
+		v.utcDOB = DaysToDate(ddays)
+		v.res.BirthDate = v.utcDOB.In(v.client.loc)
+		if int(v.tin[8]-'0')%2 == 0 {
+			v.res.Sex = Female
+		} else {
+			v.res.Sex = Male
+		}
+
+		// Judge plausibility against the clock at call time.
+		c := v.client
+		v.res.BirthDatePlausible = IsBirthDatePlausible(v.utcDOB, c.clock.Now().UTC(), c.maxAgeYears)
+
+	case StagePostChecksum:
+		v.res.ChecksumOK = ChecksumOK(v.tin)
+
+		// Without a provided DOB there is nothing to mismatch.
+		v.res.DOBMatched = v.providedDOB == nil || sameYMD(v.utcDOB, v.providedDOB.In(time.UTC))
+	}
+	return nil
+}
+
+// collect records err (flattening nested ValidationErrors).
+func (v *validation) collect(err error) {
+	if errs, ok := err.(ValidationErrors); ok {
+		v.errs = append(v.errs, errs...)
+		return
+	}
+	v.errs = append(v.errs, err)
+}
+
+// builtinSteps returns the core rules in their default order.
+func builtinSteps() []step {
+	structural := func(name string, prio int, r Rule[string]) step {
+		return step{
+			NamedRule: NamedRule{Name: name, Stage: StageStructural, Priority: prio, Check: r},
+			builtin:   true,
+			run:       func(v *validation) error { return r(v.tin) },
+		}
+	}
+	return []step{
+		structural(RuleAllDigits, 100, ruleAllDigits()),
+		structural(RuleLength, 200, ruleLength(10)),
+		structural(RuleNotAllSame, 300, ruleNotAllSame()),
+		{
+			NamedRule: NamedRule{Name: RulePlausible, Stage: StageDecoded, Priority: 100},
+			builtin:   true,
+			run: func(v *validation) error {
+				if v.res.BirthDatePlausible {
+					return nil
+				}
+				return wrapErr(
+					ErrBirthOutOfRange, v.tin,
+					"encoded birth date out of plausible range", &v.utcDOB, v.providedDOB,
+				)
+			},
+		},
+		{
+			// A failed checksum only makes the result invalid, unless all
+			// errors are being collected.
+			NamedRule: NamedRule{Name: RuleChecksum, Stage: StagePostChecksum, Priority: 100},
+			builtin:   true,
+			run: func(v *validation) error {
+				if v.client.allErrors && !v.res.ChecksumOK {
+					return VerifyChecksum(v.tin)
+				}
+				return nil
+			},
+		},
+		{
+			NamedRule: NamedRule{Name: RuleDOBMatch, Stage: StagePostChecksum, Priority: 200},
+			builtin:   true,
+			run: func(v *validation) error {
+				if !v.client.strict || v.res.DOBMatched {
+					return nil
+				}
+				return wrapErr(
+					ErrDOBMismatch, v.tin,
+					"provided DOB does not match encoded date",
+					&v.utcDOB, v.providedDOB,
+				)
+			},
+		},
+	}
+}
+
+// customStep wraps a caller-supplied rule.
+func customStep(r NamedRule) step {
+	r.Stage = max(StagePreNormalize, min(r.Stage, StagePostChecksum))
+	if check := r.CheckContext; check != nil {
+		r.Stage = max(r.Stage, StageDecoded)
+		name, timeout := r.Name, r.Timeout
+		return step{
+			NamedRule: r,
+			run: func(v *validation) error {
+				ctx := v.ctx
+				if timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, timeout)
+					defer cancel()
+				}
+				err := check(ctx, v.res)
+				if err != nil && v.ctx.Err() != nil {
+					// The caller's context ended, not the rule's own timeout.
+					return v.ctx.Err()
+				}
+				if errors.Is(err, context.DeadlineExceeded) {
+					return &Error{
+						Code: CodeRuleTimeout,
+						TIN:  v.tin,
+						Msg:  fmt.Sprintf("rule %s timed out", name),
+						Rule: name,
+						Err:  err,
+					}
+				}
+				return err
+			},
+		}
+	}
+	if check := r.CheckResult; check != nil {
+		r.Stage = max(r.Stage, StageDecoded)
+		return step{
+			NamedRule: r,
+			run:       func(v *validation) error { return check(v.res) },
+		}
+	}
+	check := r.Check
+	return step{
+		NamedRule: r,
+		run:       func(v *validation) error { return check(v.tin) },
+	}
+}
+
+// build assembles the client's pipeline from the built-in rules, the rules
+// given to WithRules and the named rules, minus any disabled names.
+func (c *Client) build() {
+	overridden := make(map[string]bool, len(c.rules))
+	for _, r := range c.rules {
+		overridden[r.Name] = true
+	}
+
+	var steps []step
+	for _, st := range builtinSteps() {
+		if !overridden[st.Name] {
+			steps = append(steps, st)
+		}
+	}
+	// Rules passed to WithRules run after the built-in structural checks.
+	for i, r := range c.custom {
+		steps = append(steps, customStep(NamedRule{
+			Name:     fmt.Sprintf("custom.%d", i),
+			Stage:    StageStructural,
+			Priority: 1000,
+			Check:    r,
+		}))
+	}
+	// Rules passed to WithResultRules run once everything is decoded.
+	for i, r := range c.resultRules {
+		steps = append(steps, customStep(NamedRule{
+			Name:        fmt.Sprintf("result.%d", i),
+			Stage:       StagePostChecksum,
+			Priority:    1000,
+			CheckResult: r,
+		}))
+	}
+	for _, r := range c.rules {
+		if r.Check != nil || r.CheckResult != nil || r.CheckContext != nil {
+			steps = append(steps, customStep(r))
+		}
+	}
+
+	steps = slices.DeleteFunc(steps, func(st step) bool { return c.disabled[st.Name] })
+	slices.SortStableFunc(steps, func(a, b step) int {
+		return cmp.Or(cmp.Compare(a.Stage, b.Stage), cmp.Compare(a.Priority, b.Priority))
+	})
+
+	c.pipeline = [stageCount][]step{}
+	c.checksumRequired = false
+	for _, st := range steps {
+		if st.builtin && st.Name == RuleChecksum {
+			c.checksumRequired = true
+		}
+		c.pipeline[st.Stage] = append(c.pipeline[st.Stage], st)
+	}
+}
+
+// annotate attaches the rule name and the TIN being validated to err.
+// Plain errors from custom rules are wrapped in an *Error so both are
+// always available; they get the code registered for a sentinel they
+// match, or CodeRule.
+func annotate(err error, name, tin string) error {
+	switch e := err.(type) {
+	case *Error:
+		if e.Rule != "" && e.TIN != "" {
+			return e
+		}
+		cp := *e
+		if cp.Rule == "" {
+			cp.Rule = name
+		}
+		if cp.TIN == "" {
+			cp.TIN = tin
+		}
+		return &cp
+	case ValidationErrors:
+		out := make(ValidationErrors, len(e))
+		for i, err := range e {
+			out[i] = annotate(err, name, tin)
+		}
+		return out
+	default:
+		code, ok := matchCode(err)
+		if !ok {
+			code = CodeRule
+		}
+		return &Error{
+			Code: code,
+			TIN:  tin,
+			Msg:  err.Error(),
+			Rule: name,
+			Err:  err,
+		}
+	}
+}
+
+// WithRule registers named rules. A rule replaces any earlier rule with the
+// same name, including a built-in one.
+func WithRule(rules ...NamedRule) Option {
+	return func(c *Client) {
+		for _, r := range rules {
+			c.rules = slices.DeleteFunc(c.rules, func(old NamedRule) bool { return old.Name == r.Name })
+			c.rules = append(c.rules, r)
+		}
+	}
+}
+
+// WithoutRules disables rules by name, built-in or custom.
+func WithoutRules(names ...string) Option {
+	return func(c *Client) {
+		if c.disabled == nil {
+			c.disabled = make(map[string]bool, len(names))
+		}
+		for _, name := range names {
+			c.disabled[name] = true
+		}
+	}
+}
+
+// WithResultRules sets rules that receive the fully decoded Result. They run
+// at StagePostChecksum, after the built-in rules, and replace any rules
+// given to an earlier WithResultRules.
+func WithResultRules(r Rules[Result]) Option {
+	return func(c *Client) {
+		c.resultRules = slices.Clone(r)
+	}
+}
+
+// ResultRules sets rules that receive the fully decoded Result. Returns a derived client for chaining.
+func (c *Client) ResultRules(r Rules[Result]) *Client {
+	return c.derive(WithResultRules(r))
+}
+
+// AddRule registers named rules. Returns a derived client for chaining.
+func (c *Client) AddRule(rules ...NamedRule) *Client {
+	return c.derive(WithRule(rules...))
+}
+
+// DisableRules disables rules by name. Returns a derived client for chaining.
+func (c *Client) DisableRules(names ...string) *Client {
+	return c.derive(WithoutRules(names...))
+}
+
+// EnableRules re-enables rules disabled earlier. Returns a derived client for chaining.
+func (c *Client) EnableRules(names ...string) *Client {
+	return c.derive(func(c *Client) {
+		for _, name := range names {
+			delete(c.disabled, name)
+		}
+	})
+}
+
+// RuleNames returns the names of the enabled rules in execution order.
+func (c *Client) RuleNames() []string {
+	var names []string
+	for _, steps := range c.pipeline {
+		for _, st := range steps {
+			names = append(names, st.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,213 @@
+package uatins
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRuleNames_DefaultOrder(t *testing.T) {
+	got := NewClient().RuleNames()
+	want := []string{RuleAllDigits, RuleLength, RuleNotAllSame, RulePlausible, RuleChecksum, RuleDOBMatch}
+	if !slices.Equal(got, want) {
+		t.Fatalf("RuleNames = %v, want %v", got, want)
+	}
+}
+
+func TestNamedRule_Priority(t *testing.T) {
+	noop := func(string) error { return nil }
+	client := NewClient(
+		WithRule(
+			NamedRule{Name: "late", Stage: StageStructural, Priority: 250, Check: noop},
+			NamedRule{Name: "early", Stage: StageStructural, Priority: 50, Check: noop},
+			NamedRule{Name: "raw", Stage: StagePreNormalize, Check: noop},
+		),
+		WithRules(Rules[string]{noop}),
+	)
+	want := []string{"raw", "early", RuleAllDigits, RuleLength, "late", RuleNotAllSame, "custom.0"}
+	if got := client.RuleNames(); !slices.Equal(got[:len(want)], want) {
+		t.Fatalf("RuleNames = %v, want prefix %v", got, want)
+	}
+}
+
+func TestNamedRule_DisableBuiltin(t *testing.T) {
+	// 1111111111 decodes to a plausible birth date (1930-06-03).
+	client := NewClient()
+	if _, err := client.Validate("1111111111", nil); !errorsIs(err, ErrAllSame) {
+		t.Fatalf("expected ErrAllSame, got %v", err)
+	}
+
+	legacy := client.DisableRules(RuleNotAllSame)
+	res, err := legacy.Validate("1111111111", nil)
+	if err != nil || !res.BirthDatePlausible || res.Sex != Male {
+		t.Fatalf("expected a decoded result with %s disabled, got %+v, %v", RuleNotAllSame, res, err)
+	}
+
+	if _, err := legacy.EnableRules(RuleNotAllSame).Validate("1111111111", nil); !errorsIs(err, ErrAllSame) {
+		t.Fatalf("expected ErrAllSame after re-enabling, got %v", err)
+	}
+}
+
+func TestNamedRule_ErrorCarriesName(t *testing.T) {
+	blocked := errors.New("blocked")
+	client := NewClient(WithRule(NamedRule{
+		Name:  "blocklist",
+		Stage: StageDecoded,
+		Check: func(string) error { return blocked },
+	}))
+
+	// A decoded-stage rule fires before the checksum is looked at.
+	_, err := client.Validate("3036045687", nil)
+	var e *Error
+	if !errors.As(err, &e) || e.Rule != "blocklist" || !errorsIs(err, ErrRule) {
+		t.Fatalf("expected ErrRule from blocklist, got %#v", err)
+	}
+	if !errors.Is(err, blocked) || err.Error() != "blocked" {
+		t.Fatalf("expected the rule's own error to be wrapped, got %v", err)
+	}
+
+	if e.TIN != "3036045687" {
+		t.Fatalf("expected the TIN on the wrapper, got %q", e.TIN)
+	}
+
+	_, err = client.Validate("12", nil)
+	if !errors.As(err, &e) || e.Rule != RuleLength {
+		t.Fatalf("expected the built-in rule name, got %#v", err)
+	}
+}
+
+func TestNamedRule_ReplaceBuiltin(t *testing.T) {
+	// Accept DOB mismatches silently even in strict mode.
+	client := NewClient(
+		WithStrict(true),
+		WithRule(NamedRule{Name: RuleDOBMatch, Stage: StagePostChecksum, Check: func(string) error { return nil }}),
+	)
+	dob := time.Date(1983, 2, 15, 0, 0, 0, 0, time.UTC)
+	res, err := client.Validate("3036045681", &dob)
+	if err != nil || res.DOBMatched {
+		t.Fatalf("expected no mismatch error, got %+v, %v", res, err)
+	}
+}
+
+func TestResultRules(t *testing.T) {
+	var seen Result
+	capture := func(r Result) error {
+		seen = r
+		return nil
+	}
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+
+	client := NewClient(WithResultRules(Rules[Result]{capture}))
+	if _, err := client.Validate("3036045681", &dob); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if seen.TIN != "3036045681" || seen.Sex != Female || !seen.ChecksumOK || !seen.DOBMatched {
+		t.Fatalf("result rule saw incomplete result: %+v", seen)
+	}
+	if seen.ProvidedDOB == nil || !seen.ProvidedDOB.Equal(dob) {
+		t.Fatalf("result rule did not see the provided DOB: %+v", seen.ProvidedDOB)
+	}
+}
+
+func TestNamedRule_CheckResultStage(t *testing.T) {
+	client := NewClient(WithRule(NamedRule{
+		Name:  "no_minors",
+		Stage: StageStructural, // bumped to StageDecoded
+		CheckResult: func(r Result) error {
+			if r.BirthDate.After(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)) {
+				return errors.New("minor")
+			}
+			return nil
+		},
+	}))
+
+	names := client.RuleNames()
+	if i := slices.Index(names, "no_minors"); i < 0 || names[i+1] != RulePlausible {
+		t.Fatalf("expected no_minors right before %s, got %v", RulePlausible, names)
+	}
+
+	if _, err := client.Validate("3036045681", nil); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	minor := NewGenerator(WithSeed(1)).MustGenerate(time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC), Male)
+	if _, err := client.Validate(minor, nil); !errorsIs(err, ErrRule) {
+		t.Fatalf("expected ErrRule for %s, got %v", minor, err)
+	}
+}
+
+type ctxKey struct{}
+
+func TestContextRule(t *testing.T) {
+	blocked := map[string]bool{"3036045681": true}
+	client := NewClient(WithRule(NamedRule{
+		Name: "blocklist",
+		CheckContext: func(ctx context.Context, r Result) error {
+			if ctx.Value(ctxKey{}) != "tenant-a" {
+				return errors.New("context not propagated")
+			}
+			if blocked[r.TIN] {
+				return errors.New("blocked")
+			}
+			return nil
+		},
+	}))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "tenant-a")
+	_, err := client.ValidateContext(ctx, "3036045681", nil)
+	var e *Error
+	if !errors.As(err, &e) || e.Rule != "blocklist" || err.Error() != "blocked" {
+		t.Fatalf("expected blocklist failure, got %v", err)
+	}
+}
+
+func TestContextRule_Timeout(t *testing.T) {
+	client := NewClient(WithRule(NamedRule{
+		Name:    "slow",
+		Timeout: 10 * time.Millisecond,
+		CheckContext: func(ctx context.Context, _ Result) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return nil
+			}
+		},
+	}))
+
+	_, err := client.ValidateContext(context.Background(), "3036045681", nil)
+	var e *Error
+	if !errors.As(err, &e) || !errorsIs(err, ErrRuleTimeout) || e.Rule != "slow" {
+		t.Fatalf("expected ErrRuleTimeout from slow, got %#v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline error to be wrapped, got %v", err)
+	}
+}
+
+func TestContextRule_CallerDeadline(t *testing.T) {
+	client := NewClient(WithRule(NamedRule{
+		Name:    "slow",
+		Timeout: time.Hour,
+		CheckContext: func(ctx context.Context, _ Result) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.ValidateContext(ctx, "3036045681", nil)
+	if err != context.DeadlineExceeded || errorsIs(err, ErrRuleTimeout) {
+		t.Fatalf("expected the caller's deadline, got %#v", err)
+	}
+}
+
+func TestValidateContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewClient().ValidateContext(ctx, "3036045681", nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
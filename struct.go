@@ -0,0 +1,371 @@
+package uatins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FieldError is a validation failure of one struct field, reported by
+// ValidateStruct. Field is the path from the root value, e.g.
+// "Employees[2].Passport.TIN".
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying validation error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON encodes e like an Error with an extra "field" member.
+func (e *FieldError) MarshalJSON() ([]byte, error) {
+	var te *Error
+	if !errors.As(e.Err, &te) {
+		te = &Error{Code: CodeOf(e.Err), Msg: e.Err.Error()}
+	}
+	return json.Marshal(struct {
+		Field string `json:"field"`
+		errorJSON
+	}{e.Field, te.wire()})
+}
+
+// tagName is the struct tag read by ValidateStruct.
+const tagName = "uatins"
+
+// tinTag is the parsed form of a `uatins:"tin,..."` tag.
+type tinTag struct {
+	dob       string // name of the sibling birth date field
+	sex       string // name of the sibling sex field
+	strict    bool   // the dob field must be set
+	omitempty bool
+}
+
+var (
+	sexType      = reflect.TypeFor[Sex]()
+	timeType     = reflect.TypeFor[time.Time]()
+	nullTINType  = reflect.TypeFor[NullTIN]()
+	structFields sync.Map // reflect.Type -> []structField
+)
+
+// structField is an exported field of a struct type and its tag, if any.
+type structField struct {
+	index int
+	name  string
+	tag   *tinTag
+	dob   int // index of the dob field, or -1
+	sex   int // index of the sex field, or -1
+}
+
+// ValidateStruct validates the TIN fields of v with the default client.
+// See Client.ValidateStruct.
+func ValidateStruct(v any) error {
+	return defaultClient.ValidateStruct(v)
+}
+
+// ValidateStruct validates every field of the struct v (or pointer to
+// struct) tagged `uatins:"tin"`, descending into nested structs, pointers,
+// slices, arrays and maps. Tag options, after "tin":
+//
+//	dob=Field   cross-check the decoded birth date against the sibling
+//	            time.Time or *time.Time field (zero or nil skips it); a
+//	            mismatch is reported even if the client is not strict
+//	sex=Field   cross-check the decoded sex against the sibling Sex or
+//	            string field (empty skips it)
+//	strict      with dob=, report an empty birth date field as
+//	            CodeDOBMissing instead of skipping the cross-check
+//	omitempty   skip the field when it is empty
+//
+// Tagged fields may be a string type (string, TIN), a pointer to one (nil
+// skips it) or a NullTIN. The returned error is nil or ValidationErrors of
+// *FieldError, one per failing field. A malformed tag is reported as a
+// plain error.
+//
+//	type Employee struct {
+//		TIN       string    `uatins:"tin,dob=BirthDate,sex=Gender"`
+//		BirthDate time.Time
+//		Gender    uatins.Sex
+//	}
+func (c *Client) ValidateStruct(v any) error {
+	return c.ValidateStructContext(context.Background(), v)
+}
+
+// ValidateStructContext is like ValidateStruct but passes ctx to Validate.
+func (c *Client) ValidateStructContext(ctx context.Context, v any) error {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("tin: ValidateStruct needs a struct, got %T", v)
+	}
+
+	w := structWalker{ctx: ctx, client: c, seen: make(map[uintptr]bool)}
+	if err := w.walk(reflect.ValueOf(v), ""); err != nil {
+		return err
+	}
+	if len(w.errs) == 0 {
+		return nil
+	}
+	return w.errs
+}
+
+// structWalker carries the state of one ValidateStruct call.
+type structWalker struct {
+	ctx    context.Context
+	client *Client
+	strict *Client // c.Strict(true), built on first use
+	seen   map[uintptr]bool
+	errs   ValidationErrors
+}
+
+// walk visits v at path. It returns an error only for malformed tags or a
+// cancelled context; field failures are collected in w.errs.
+func (w *structWalker) walk(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || w.seen[v.Pointer()] {
+			return nil
+		}
+		w.seen[v.Pointer()] = true
+		return w.walk(v.Elem(), path)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return w.walk(v.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		if !walkable(v.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !walkable(v.Type().Elem()) {
+			return nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := w.walk(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		fields, err := fieldsOf(v.Type())
+		if err != nil {
+			return err
+		}
+		for _, f := range fields {
+			fpath := f.name
+			if path != "" {
+				fpath = path + "." + f.name
+			}
+			if f.tag == nil {
+				if err := w.walk(v.Field(f.index), fpath); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := w.check(v, f, fpath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkable reports whether values of type t may contain tagged fields.
+func walkable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return walkable(t.Elem())
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// check validates the tagged field f of the struct value sv.
+func (w *structWalker) check(sv reflect.Value, f structField, path string) error {
+	tin, ok := tinValue(sv.Field(f.index))
+	if !ok || (tin == "" && f.tag.omitempty) {
+		return nil
+	}
+
+	var dob *time.Time
+	if f.dob >= 0 {
+		dob = timeValue(sv.Field(f.dob))
+	}
+	client := w.client
+	if dob != nil && !client.strict {
+		if w.strict == nil {
+			w.strict = client.Strict(true)
+		}
+		client = w.strict
+	}
+
+	res, err := client.ValidateContext(w.ctx, tin, dob)
+	if ctxErr := w.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if err == nil && !res.Valid {
+		err = client.localize(VerifyChecksum(res.TIN))
+	}
+	if err == nil && f.sex >= 0 {
+		if want := Sex(sv.Field(f.sex).String()); want != "" && want != res.Sex {
+			e := wrapErr(ErrSexMismatch, res.TIN, "provided sex does not match encoded sex", nil, nil)
+			e.Params = map[string]string{"provided_sex": string(want), "decoded_sex": string(res.Sex)}
+			err = client.localize(e)
+		}
+	}
+	if err == nil && f.tag.strict && dob == nil {
+		err = client.localize(wrapErr(ErrDOBMissing, res.TIN, "birth date is missing", nil, nil))
+	}
+	if err != nil {
+		w.errs = append(w.errs, &FieldError{Field: path, Err: err})
+	}
+	return nil
+}
+
+// tinValue returns the TIN held by a tagged field; ok is false for a nil
+// pointer or an invalid NullTIN.
+func tinValue(v reflect.Value) (tin string, ok bool) {
+	if v.Type() == nullTINType {
+		n := v.Interface().(NullTIN)
+		return string(n.TIN), n.Valid
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	return v.String(), true
+}
+
+// timeValue returns the birth date held by a time.Time or *time.Time
+// field, or nil if it is unset.
+func timeValue(v reflect.Value) *time.Time {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	t := v.Interface().(time.Time)
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// fieldsOf returns the exported fields of the struct type t with their
+// parsed tags, caching the result per type.
+func fieldsOf(t reflect.Type) ([]structField, error) {
+	if cached, ok := structFields.Load(t); ok {
+		return cached.([]structField), nil
+	}
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(tagName)
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		f := structField{index: i, name: sf.Name, dob: -1, sex: -1}
+		if tag == "" {
+			if !walkable(sf.Type) {
+				continue
+			}
+			fields = append(fields, f)
+			continue
+		}
+
+		var err error
+		if f.tag, err = parseTag(tag); err != nil {
+			return nil, fmt.Errorf("tin: %s.%s: %w", t, sf.Name, err)
+		}
+		if !isTINType(sf.Type) {
+			return nil, fmt.Errorf("tin: %s.%s: a tin field must be a string, *string or NullTIN, not %s", t, sf.Name, sf.Type)
+		}
+		if f.tag.dob != "" {
+			df, ok := t.FieldByName(f.tag.dob)
+			if !ok || len(df.Index) != 1 || !df.IsExported() || (df.Type != timeType && df.Type != reflect.PointerTo(timeType)) {
+				return nil, fmt.Errorf("tin: %s.%s: dob=%s must name an exported time.Time or *time.Time field", t, sf.Name, f.tag.dob)
+			}
+			f.dob = df.Index[0]
+		}
+		if f.tag.sex != "" {
+			xf, ok := t.FieldByName(f.tag.sex)
+			if !ok || len(xf.Index) != 1 || !xf.IsExported() || xf.Type.Kind() != reflect.String {
+				return nil, fmt.Errorf("tin: %s.%s: sex=%s must name an exported Sex or string field", t, sf.Name, f.tag.sex)
+			}
+			f.sex = xf.Index[0]
+		}
+		fields = append(fields, f)
+	}
+
+	structFields.Store(t, fields)
+	return fields, nil
+}
+
+// isTINType reports whether a tagged field of type t can hold a TIN.
+func isTINType(t reflect.Type) bool {
+	if t == nullTINType {
+		return true
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.String && t != sexType
+}
+
+// parseTag parses the options of a `uatins:"tin,..."` tag.
+func parseTag(tag string) (*tinTag, error) {
+	opts := strings.Split(tag, ",")
+	if strings.TrimSpace(opts[0]) != "tin" {
+		return nil, fmt.Errorf("tag %q must start with \"tin\"", tag)
+	}
+	t := &tinTag{}
+	for _, opt := range opts[1:] {
+		key, val, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "dob", "sex":
+			if val == "" {
+				return nil, fmt.Errorf("tag option %q needs a field name", key)
+			}
+			if key == "dob" {
+				t.dob = val
+			} else {
+				t.sex = val
+			}
+		case "strict":
+			t.strict = true
+		case "omitempty":
+			t.omitempty = true
+		default:
+			return nil, fmt.Errorf("unknown tag option %q", opt)
+		}
+	}
+	if t.strict && t.dob == "" {
+		return nil, fmt.Errorf("tag option \"strict\" needs dob=")
+	}
+	return t, nil
+}
@@ -0,0 +1,193 @@
+package uatins
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type employee struct {
+	TIN       string `uatins:"tin,dob=BirthDate,sex=Gender"`
+	BirthDate time.Time
+	Gender    Sex
+	Spouse    *string `uatins:"tin,omitempty"`
+}
+
+type department struct {
+	Name       string
+	Head       employee
+	Staff      []employee
+	Deputies   map[string]*employee
+	Contractor struct {
+		Code NullTIN `uatins:"tin"`
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	ok := employee{TIN: "3036045681", BirthDate: dob, Gender: Female}
+	if err := ValidateStruct(&ok); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bad, empty := "3036045687", ""
+	d := department{
+		Head: ok,
+		Staff: []employee{
+			ok,
+			{TIN: "3036045681", BirthDate: dob.AddDate(0, 0, 1)},
+			{TIN: "3036045681", Gender: Male},
+			{TIN: "12", Spouse: &bad},
+			{TIN: "3036045681", Spouse: &empty},
+		},
+		Deputies: map[string]*employee{"ops": {TIN: "3036045687"}, "none": nil},
+	}
+	d.Contractor.Code = NullTIN{TIN: "1111111111", Valid: true}
+
+	err := NewClient().ValidateStruct(d)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	want := map[string]ErrorCode{
+		"Staff[1].TIN":      CodeDOBMismatch,
+		"Staff[2].TIN":      CodeSexMismatch,
+		"Staff[3].TIN":      CodeLength,
+		"Staff[3].Spouse":   CodeChecksum,
+		"Deputies[ops].TIN": CodeChecksum,
+		"Contractor.Code":   CodeAllSame,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d:\n%v", len(want), len(errs), err)
+	}
+	for _, e := range errs {
+		var fe *FieldError
+		if !errors.As(e, &fe) {
+			t.Fatalf("expected *FieldError, got %T", e)
+		}
+		if code, ok := want[fe.Field]; !ok || CodeOf(fe) != code {
+			t.Errorf("%s: got %s, want %s", fe.Field, CodeOf(fe), code)
+		}
+	}
+	if !errors.Is(err, ErrSexMismatch) || !strings.Contains(err.Error(), "Staff[2].TIN: provided sex does not match") {
+		t.Errorf("unexpected error text:\n%v", err)
+	}
+}
+
+func TestValidateStruct_Localize(t *testing.T) {
+	err := ValidateStruct(struct {
+		Staff []employee
+	}{Staff: []employee{{TIN: "3036045681"}, {TIN: "12"}}})
+	if got := Localize(err, LangUkrainian); got != "Staff[1].TIN: РНОКПП має містити 10 цифр" {
+		t.Fatalf("unexpected localized error %q", got)
+	}
+}
+
+func TestValidateStruct_JSON(t *testing.T) {
+	err := ValidateStruct(struct {
+		TIN TIN `uatins:"tin"`
+	}{TIN: "12"})
+	out, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+	if !strings.HasPrefix(string(out), `[{"field":"TIN","code":"LENGTH",`) {
+		t.Fatalf("unexpected JSON %s", out)
+	}
+}
+
+func TestValidateStruct_Localized(t *testing.T) {
+	err := NewClient(WithLanguage(LangUkrainian)).ValidateStruct(&employee{TIN: "3036045681", Gender: Male})
+	if err == nil || !strings.Contains(err.Error(), "вказана стать") {
+		t.Fatalf("expected a Ukrainian message, got %v", err)
+	}
+}
+
+func TestValidateStruct_BadTags(t *testing.T) {
+	tests := []any{
+		struct {
+			TIN string `uatins:"inn"`
+		}{},
+		struct {
+			TIN string `uatins:"tin,dob=Missing"`
+		}{},
+		struct {
+			TIN  string `uatins:"tin,sex=Born"`
+			Born time.Time
+		}{},
+		struct {
+			TIN   string `uatins:"tin,dob=birth"`
+			birth time.Time
+		}{TIN: "3036045681", birth: time.Now()},
+		struct {
+			TIN    string `uatins:"tin,sex=gender"`
+			gender string
+		}{TIN: "3036045681", gender: "male"},
+		struct {
+			TIN int `uatins:"tin"`
+		}{},
+		struct {
+			TIN string `uatins:"tin,loose"`
+		}{},
+		struct {
+			TIN string `uatins:"tin,strict"`
+		}{},
+		42,
+	}
+	for _, v := range tests {
+		err := ValidateStruct(v)
+		var errs ValidationErrors
+		if err == nil || errors.As(err, &errs) {
+			t.Errorf("%T: expected a configuration error, got %v", v, err)
+		}
+	}
+}
+
+func TestValidateStruct_Cycle(t *testing.T) {
+	type node struct {
+		TIN  string `uatins:"tin"`
+		Next *node
+	}
+	n := &node{TIN: "12"}
+	n.Next = n
+	err := ValidateStruct(n)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", err)
+	}
+}
+
+func TestValidateStruct_DOBWithoutStrict(t *testing.T) {
+	type form struct {
+		TIN   string `uatins:"tin,dob=Birth"`
+		Birth time.Time
+	}
+	err := NewClient().ValidateStruct(form{TIN: "3036045681", Birth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if !errors.Is(err, ErrDOBMismatch) {
+		t.Fatalf("expected a DOB mismatch, got %v", err)
+	}
+}
+
+func TestValidateStruct_StrictDOB(t *testing.T) {
+	type form struct {
+		TIN   string `uatins:"tin,dob=Birth"`
+		Must  string `uatins:"tin,dob=Birth,strict"`
+		Birth *time.Time
+	}
+	err := ValidateStruct(form{TIN: "3036045681", Must: "3036045681"})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || CodeOf(errs[0]) != CodeDOBMissing || !strings.HasPrefix(err.Error(), "Must: ") {
+		t.Fatalf("expected DOB_MISSING for Must only, got %v", err)
+	}
+
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	if err := ValidateStruct(form{TIN: "3036045681", Must: "3036045681", Birth: &dob}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A bad TIN is reported as such, not as a missing birth date.
+	if err := ValidateStruct(form{TIN: "3036045681", Must: "12"}); CodeOf(err) != CodeLength {
+		t.Fatalf("expected LENGTH, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package uatins
+
+import (
+	"sort"
+	"time"
+)
+
+// EditKind describes how a Suggestion differs from the mistyped input.
+type EditKind string
+
+const (
+	EditSubstitution  EditKind = "substitution"
+	EditTransposition EditKind = "transposition"
+)
+
+// Suggestion is a candidate correction for a TIN that fails the checksum.
+type Suggestion struct {
+	TIN       string
+	Kind      EditKind
+	Position  int // zero-based index of the first changed digit
+	BirthDate time.Time
+	Sex       Sex
+	Score     float64 // relative likelihood in (0, 1]; higher is better
+}
+
+// Likelihood weights for the supported typo classes.
+const (
+	scoreTransposition = 0.9
+	scoreNeighbour     = 0.6
+	scoreSubstitution  = 0.3
+)
+
+// neighbours lists digit pairs that sit next to each other on the top
+// keyboard row or on a numeric keypad.
+var neighbours = func() map[[2]byte]bool {
+	pairs := []string{
+		// top row: 1234567890
+		"12", "23", "34", "45", "56", "67", "78", "89", "90",
+		// numeric keypad rows and columns
+		"47", "14", "58", "25", "69", "36", "10", "20",
+	}
+	m := make(map[[2]byte]bool, 2*len(pairs))
+	for _, p := range pairs {
+		m[[2]byte{p[0], p[1]}] = true
+		m[[2]byte{p[1], p[0]}] = true
+	}
+	return m
+}()
+
+type suggestFilter struct {
+	dob *time.Time
+	sex Sex
+}
+
+// SuggestOption narrows the candidates returned by Client.Suggest.
+type SuggestOption func(*suggestFilter)
+
+// SuggestDOB keeps only candidates encoding the given birth date.
+func SuggestDOB(dob time.Time) SuggestOption {
+	return func(f *suggestFilter) {
+		f.dob = &dob
+	}
+}
+
+// SuggestSex keeps only candidates encoding the given sex.
+func SuggestSex(sex Sex) SuggestOption {
+	return func(f *suggestFilter) {
+		f.sex = sex
+	}
+}
+
+// Suggest enumerates single-digit substitutions and adjacent transpositions
+// of tin that pass the client's validation, ranked by likelihood.
+// It returns nil if tin already has a valid checksum and an error if tin
+// is structurally broken (wrong length, non-digits).
+func (c *Client) Suggest(tin string, opts ...SuggestOption) ([]Suggestion, error) {
+	var f suggestFilter
+	for _, opt := range opts {
+		opt(&f)
+	}
+
+	tin = digitsOnly(tin)
+	var core Rules[string]
+	core = core.Add(ruleAllDigits(), ruleLength(10))
+	if err := core.Validate(tin); err != nil {
+		return nil, err
+	}
+	if ChecksumOK(tin) {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool)
+	var out []Suggestion
+	try := func(b []byte, kind EditKind, pos int, score float64) {
+		cand := string(b)
+		if seen[cand] || !ChecksumOK(cand) {
+			return
+		}
+		seen[cand] = true
+		res, err := c.Validate(cand, nil)
+		if err != nil || !res.Valid {
+			return
+		}
+		if f.dob != nil && !sameYMD(res.BirthDate.In(time.UTC), f.dob.In(time.UTC)) {
+			return
+		}
+		if f.sex != "" && res.Sex != f.sex {
+			return
+		}
+		out = append(out, Suggestion{
+			TIN:       cand,
+			Kind:      kind,
+			Position:  pos,
+			BirthDate: res.BirthDate,
+			Sex:       res.Sex,
+			Score:     score,
+		})
+	}
+
+	b := []byte(tin)
+	for i := 0; i+1 < len(b); i++ {
+		if b[i] == b[i+1] {
+			continue
+		}
+		b[i], b[i+1] = b[i+1], b[i]
+		try(b, EditTransposition, i, scoreTransposition)
+		b[i], b[i+1] = b[i+1], b[i]
+	}
+	for i := range b {
+		orig := b[i]
+		for d := byte('0'); d <= '9'; d++ {
+			if d == orig {
+				continue
+			}
+			b[i] = d
+			score := scoreSubstitution
+			if neighbours[[2]byte{orig, d}] {
+				score = scoreNeighbour
+			}
+			try(b, EditSubstitution, i, score)
+		}
+		b[i] = orig
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].TIN < out[j].TIN
+	})
+	return out, nil
+}
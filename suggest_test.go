@@ -0,0 +1,49 @@
+package uatins
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSuggest_Transposition(t *testing.T) {
+	client := NewClient()
+	got, err := client.Suggest("3036045618")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("expected suggestions")
+	}
+	top := got[0]
+	if top.TIN != "3036045681" || top.Kind != EditTransposition || top.Position != 8 {
+		t.Fatalf("unexpected top suggestion: %+v", top)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Score > got[i-1].Score {
+			t.Fatalf("suggestions not ranked: %+v", got)
+		}
+	}
+}
+
+func TestSuggest_Filters(t *testing.T) {
+	client := NewClient()
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	got, err := client.Suggest("3036045618", SuggestDOB(dob), SuggestSex(Female))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].TIN != "3036045681" {
+		t.Fatalf("expected a single candidate, got %+v", got)
+	}
+}
+
+func TestSuggest_ValidAndBroken(t *testing.T) {
+	client := NewClient()
+	got, err := client.Suggest("3036045681")
+	if err != nil || got != nil {
+		t.Fatalf("expected no suggestions for a valid TIN, got %+v, %v", got, err)
+	}
+	if _, err := client.Suggest("303604568"); !errorsIs(err, ErrLength) {
+		t.Fatalf("expected ErrLength, got %v", err)
+	}
+}
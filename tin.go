@@ -0,0 +1,176 @@
+package uatins
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TIN is a taxpayer number that has passed validation. Construct it with
+// Parse; decoding from JSON, text or a database column validates as well.
+// The zero value is the empty TIN.
+type TIN string
+
+// defaultClient backs Parse and the TIN decoders.
+var defaultClient = NewClient()
+
+// Parse validates s with default settings and returns it as a TIN.
+// A number that is well-formed but fails the checksum yields ErrChecksum.
+func Parse(s string) (TIN, error) {
+	return defaultClient.Parse(s)
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(s string) TIN {
+	t, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Parse validates s with the client's settings and returns it as a TIN.
+func (c *Client) Parse(s string) (TIN, error) {
+	res, err := c.Validate(s, nil)
+	if err != nil {
+		return "", err
+	}
+	if !res.Valid {
+		if err := VerifyChecksum(res.TIN); err != nil {
+			return "", err
+		}
+		return "", wrapErr(ErrUnknown, res.TIN, "tin is not valid", nil, nil)
+	}
+	return TIN(res.TIN), nil
+}
+
+// String returns the 10 digits of t.
+func (t TIN) String() string {
+	return string(t)
+}
+
+// BirthDate returns the birth date encoded in t, or the zero time if t is empty.
+func (t TIN) BirthDate() time.Time {
+	d, err := DecodeDOBFromTIN(string(t))
+	if err != nil {
+		return time.Time{}
+	}
+	return d
+}
+
+// Sex returns the sex encoded in t, or "" if t is empty.
+func (t TIN) Sex() Sex {
+	if len(t) != 10 {
+		return ""
+	}
+	if int(t[8]-'0')%2 == 0 {
+		return Female
+	}
+	return Male
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TIN) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It rejects invalid TINs.
+func (t *TIN) UnmarshalText(text []byte) error {
+	p, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*t = p
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t TIN) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It rejects invalid TINs;
+// JSON null leaves t unchanged.
+func (t *TIN) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner. It rejects invalid TINs and NULL; use
+// NullTIN for nullable columns.
+func (t *TIN) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	case int64:
+		return t.UnmarshalText(fmt.Appendf(nil, "%010d", v))
+	case nil:
+		return fmt.Errorf("tin: cannot scan NULL into TIN; use NullTIN")
+	default:
+		return fmt.Errorf("tin: cannot scan %T into TIN", src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (t TIN) Value() (driver.Value, error) {
+	return string(t), nil
+}
+
+// NullTIN is a TIN that may be null, for nullable database columns and
+// optional JSON fields.
+type NullTIN struct {
+	TIN   TIN
+	Valid bool // Valid is true if TIN is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullTIN) Scan(src any) error {
+	if src == nil {
+		n.TIN, n.Valid = "", false
+		return nil
+	}
+	if err := n.TIN.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullTIN) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.TIN.Value()
+}
+
+// MarshalJSON implements json.Marshaler; a null TIN encodes as JSON null.
+func (n NullTIN) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.TIN.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullTIN) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		n.TIN, n.Valid = "", false
+		return nil
+	}
+	if err := n.TIN.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
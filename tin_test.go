@@ -0,0 +1,79 @@
+package uatins
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tin, err := Parse("3036-045-681")
+	if err != nil || tin != "3036045681" {
+		t.Fatalf("Parse = %q, %v", tin, err)
+	}
+	if !sameYMD(tin.BirthDate(), time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)) || tin.Sex() != Female {
+		t.Fatalf("unexpected decoded fields: %s %s", tin.BirthDate(), tin.Sex())
+	}
+
+	if _, err := Parse("3036045687"); !errorsIs(err, ErrChecksum) {
+		t.Fatalf("expected ErrChecksum, got %v", err)
+	}
+	if _, err := Parse("12"); !errorsIs(err, ErrLength) {
+		t.Fatalf("expected ErrLength, got %v", err)
+	}
+}
+
+func TestTIN_JSON(t *testing.T) {
+	type body struct {
+		TIN     TIN     `json:"tin"`
+		Partner NullTIN `json:"partner"`
+	}
+
+	var b body
+	if err := json.Unmarshal([]byte(`{"tin":"3036045681","partner":null}`), &b); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if b.TIN != "3036045681" || b.Partner.Valid {
+		t.Fatalf("unexpected decode: %+v", b)
+	}
+	out, err := json.Marshal(b)
+	if err != nil || string(out) != `{"tin":"3036045681","partner":null}` {
+		t.Fatalf("Marshal = %s, %v", out, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"tin":"3036045687"}`), &b); !errorsIs(err, ErrChecksum) {
+		t.Fatalf("expected ErrChecksum, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"tin":"3036045681","partner":"1234567890"}`), &b); err == nil {
+		t.Fatal("expected invalid partner TIN to be rejected")
+	}
+}
+
+func TestTIN_SQL(t *testing.T) {
+	var tin TIN
+	for _, src := range []any{"3036045681", []byte("3036045681"), int64(3036045681)} {
+		if err := tin.Scan(src); err != nil || tin != "3036045681" {
+			t.Fatalf("Scan(%T) = %q, %v", src, tin, err)
+		}
+	}
+	if err := tin.Scan(nil); err == nil {
+		t.Fatal("expected NULL to be rejected by TIN")
+	}
+	if err := tin.Scan("3036045687"); !errorsIs(err, ErrChecksum) {
+		t.Fatalf("expected ErrChecksum, got %v", err)
+	}
+	if v, err := tin.Value(); err != nil || v != "3036045681" {
+		t.Fatalf("Value = %v, %v", v, err)
+	}
+
+	var n NullTIN
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("Scan(nil) = %+v, %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Fatalf("Value = %v, %v", v, err)
+	}
+	if err := n.Scan("3036045681"); err != nil || !n.Valid || n.TIN != "3036045681" {
+		t.Fatalf("Scan = %+v, %v", n, err)
+	}
+}
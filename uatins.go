@@ -1,8 +1,11 @@
 package uatins
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -25,9 +28,13 @@ type Result struct {
 	BirthDatePlausible bool
 	DOBMatched         bool
 	Valid              bool
+
+	// ProvidedDOB is the birth date passed to Validate, if any.
+	ProvidedDOB *time.Time
 }
 
-// Custom errors for various validation failures.
+// Custom errors for various validation failures. Each has an ErrorCode;
+// errors.Is matches an *Error against the sentinel for its Code.
 var (
 	ErrLength          = errors.New("tin: invalid length")
 	ErrNonDigit        = errors.New("tin: contains non-digit")
@@ -35,38 +42,93 @@ var (
 	ErrChecksum        = errors.New("tin: checksum failed")
 	ErrBirthOutOfRange = errors.New("tin: birth date not plausible")
 	ErrDOBMismatch     = errors.New("tin: provided DOB does not match encoded date")
+	ErrSexMismatch     = errors.New("tin: provided sex does not match encoded sex")
+	ErrDOBMissing      = errors.New("tin: birth date is missing")
+	ErrRule            = errors.New("tin: rule failed")
+	ErrRuleTimeout     = errors.New("tin: rule timed out")
 	ErrUnknown         = errors.New("tin: unknown error")
+	ErrDOBFormat       = errors.New("tin: birth date has an unknown format")
+	ErrBadRequest      = errors.New("tin: malformed request")
 )
 
 // Error contains context for validation errors.
 type Error struct {
-	Code        string
+	Code        ErrorCode
 	TIN         string
 	Msg         string
 	DecodedDOB  *time.Time
 	ProvidedDOB *time.Time
+
+	// ExpectedDigit and ActualDigit are set for ErrChecksum: the control
+	// digit computed from the first nine digits and the one actually given.
+	ExpectedDigit *int
+	ActualDigit   *int
+
+	// Params holds extra values for localized messages, e.g. "length".
+	Params map[string]string
+
+	// Rule is the name of the rule that reported the error, if known.
+	Rule string
+	// Err is the underlying error returned by a custom rule, if any.
+	Err error
 }
 
 func (e *Error) Error() string {
 	if e.Msg != "" {
 		return e.Msg
 	}
-	return e.Code
+	if s := e.Code.Sentinel(); s != nil {
+		return s.Error()
+	}
+	return string(e.Code)
 }
 
+// Unwrap returns the error reported by a custom rule, if any.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel registered for e.Code.
 func (e *Error) Is(target error) bool {
-	switch target {
-	case ErrLength, ErrNonDigit, ErrAllSame, ErrChecksum, ErrBirthOutOfRange, ErrDOBMismatch:
-		return e.Code == target.Error()
-	default:
-		return false
+	code, ok := codeOfSentinel(target)
+	return ok && e.Code == code
+}
+
+// ValidationErrors collects every failure reported by an accumulating
+// validation (Rules.ValidateAll or a Client built WithAllErrors). It
+// unwraps like errors.Join, so errors.Is and errors.As see each entry.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the collected errors.
+func (e ValidationErrors) Unwrap() []error {
+	return e
+}
+
+// Codes returns the code of every error in e, in order.
+func (e ValidationErrors) Codes() []ErrorCode {
+	codes := make([]ErrorCode, len(e))
+	for i, err := range e {
+		codes[i] = CodeOf(err)
 	}
+	return codes
 }
 
 // wrapErr constructs a detailed Error from a sentinel.
 func wrapErr(sentinel error, tin string, msg string, dec, prov *time.Time) *Error {
+	code, ok := codeOfSentinel(sentinel)
+	if !ok {
+		code = CodeUnknown
+	}
 	return &Error{
-		Code:        sentinel.Error(),
+		Code:        code,
 		TIN:         tin,
 		Msg:         msg,
 		DecodedDOB:  dec,
@@ -95,25 +157,57 @@ func (r Rules[T]) Validate(v T) error {
 	return nil
 }
 
-// Client is a reusable TIN validator.
+// ValidateAll runs every rule and returns all failures as ValidationErrors,
+// or nil if every rule passes.
+func (r Rules[T]) ValidateAll(v T) error {
+	var errs ValidationErrors
+	for _, rule := range r {
+		if err := rule(v); err != nil {
+			var nested ValidationErrors
+			if errors.As(err, &nested) {
+				errs = append(errs, nested...)
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Client is a reusable TIN validator. A Client is immutable once built:
+// chain methods return a derived copy and leave the receiver untouched,
+// so one Client may be shared by any number of goroutines.
 type Client struct {
-	now         time.Time
+	clock       Clock
 	maxAgeYears int
 	strict      bool
 	loc         *time.Location
 	custom      Rules[string]
+	resultRules Rules[Result]
+	rules       []NamedRule
+	disabled    map[string]bool
+	workers     int
+	allErrors   bool
+	lang        string
+	pipeline    [stageCount][]step
+
+	checksumRequired bool
 }
 
 // NewClient returns a new Client with sane defaults.
 func NewClient(opts ...Option) *Client {
 	c := &Client{
-		now:         time.Now().UTC(),
+		clock:       SystemClock(),
 		maxAgeYears: 130,
 		loc:         time.UTC,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.build()
 	return c
 }
 
@@ -146,119 +240,114 @@ func WithLocation(loc *time.Location) Option {
 // WithRules allows callers to extend or override rules.
 func WithRules(r Rules[string]) Option {
 	return func(c *Client) {
-		c.custom = r
+		c.custom = slices.Clone(r)
 	}
 }
 
-// WithNow overrides the current time (useful for tests).
-func WithNow(t time.Time) Option {
+// WithAllErrors makes Validate report every failed rule as ValidationErrors
+// instead of stopping at the first one. Checksum failures are reported as
+// ErrChecksum in this mode. Custom rules and decoding still need exactly
+// 10 digits, so they are skipped when the length is wrong.
+func WithAllErrors(on bool) Option {
 	return func(c *Client) {
-		c.now = t.In(time.UTC)
+		c.allErrors = on
 	}
 }
 
-// MaxAge sets an age cap; 0 disables the cap. Returns the client for chaining.
+// WithNow overrides the current time (useful for tests).
+//
+// Deprecated: use WithClock(FixedClock(t)).
+func WithNow(t time.Time) Option {
+	return WithClock(FixedClock(t))
+}
+
+// MaxAge sets an age cap; 0 disables the cap. Returns a derived client for chaining.
 func (c *Client) MaxAge(years int) *Client {
-	c.maxAgeYears = years
-	return c
+	return c.derive(WithMaxAge(years))
 }
 
-// Strict enforces DOB mismatch as a validation error. Returns the client for chaining.
+// Strict enforces DOB mismatch as a validation error. Returns a derived client for chaining.
 func (c *Client) Strict(on bool) *Client {
-	c.strict = on
-	return c
+	return c.derive(WithStrict(on))
 }
 
-// Location sets the time zone used to expose the BirthDate. Returns the client for chaining.
+// Location sets the time zone used to expose the BirthDate. Returns a derived client for chaining.
 func (c *Client) Location(loc *time.Location) *Client {
-	if loc != nil {
-		c.loc = loc
-	}
-	return c
+	return c.derive(WithLocation(loc))
 }
 
-// Rules allows callers to extend or override rules. Returns the client for chaining.
+// Rules allows callers to extend or override rules. Returns a derived client for chaining.
 func (c *Client) Rules(r Rules[string]) *Client {
-	c.custom = r
-	return c
+	return c.derive(WithRules(r))
 }
 
-// Now overrides the current time (useful for tests). Returns the client for chaining.
+// Now overrides the current time (useful for tests). Returns a derived client for chaining.
+//
+// Deprecated: use c.Clock(FixedClock(t)).
 func (c *Client) Now(t time.Time) *Client {
-	c.now = t.In(time.UTC)
-	return c
+	return c.Clock(FixedClock(t))
 }
 
-// Validate runs all checks and returns a Result and an error (if any).
-func (c *Client) Validate(tin string, providedDOB *time.Time) (Result, error) {
-	var res Result
-	tin = digitsOnly(tin)
-	res.TIN = tin
-
-	// Core string rules: non-digit first, then length, then all-same.
-	var core Rules[string]
-	core = core.Add(
-		ruleAllDigits(),  // ensure only digits first
-		ruleLength(10),   // enforce exact length next
-		ruleNotAllSame(), // disallow all-same digits or all zeros
-	)
-	if err := core.Validate(tin); err != nil {
-		return res, err
-	}
-
-	// Custom rules, if any.
-	if c.custom != nil {
-		if err := c.custom.Validate(tin); err != nil {
-			return res, err
-		}
-	}
-
-	// Decode DOB from digits 1..5 and sex from digit 9.
-	ddays, _ := strconv.Atoi(tin[:5])
-	utcDOB := DaysToDate(ddays)
-	res.BirthDate = utcDOB.In(c.loc)
-
-	if int(tin[8]-'0')%2 == 0 {
-		res.Sex = Female
-	} else {
-		res.Sex = Male
-	}
+// AllErrors makes Validate report every failed rule. Returns a derived client for chaining.
+func (c *Client) AllErrors(on bool) *Client {
+	return c.derive(WithAllErrors(on))
+}
 
-	// Check if the birth date is plausible.
-	if !IsBirthDatePlausible(utcDOB, c.now, c.maxAgeYears) {
-		return res, wrapErr(
-			ErrBirthOutOfRange, tin,
-			"encoded birth date out of plausible range", &utcDOB, providedDOB,
-		)
+// derive returns a copy of c with opts applied and its pipeline rebuilt.
+func (c *Client) derive(opts ...Option) *Client {
+	cp := *c
+	cp.custom = slices.Clone(c.custom)
+	cp.resultRules = slices.Clone(c.resultRules)
+	cp.rules = slices.Clone(c.rules)
+	cp.disabled = maps.Clone(c.disabled)
+	for _, opt := range opts {
+		opt(&cp)
 	}
-	res.BirthDatePlausible = true
+	cp.build()
+	return &cp
+}
 
-	// Compute the checksum result. Do not return an error if it fails;
-	// just set res.Valid accordingly below.
-	res.ChecksumOK = ChecksumOK(tin)
+// Validate runs all checks and returns a Result and an error (if any).
+// With WithAllErrors the error, if any, is a ValidationErrors.
+func (c *Client) Validate(tin string, providedDOB *time.Time) (Result, error) {
+	return c.ValidateContext(context.Background(), tin, providedDOB)
+}
 
-	// Compare provided DOB if supplied.
-	if providedDOB != nil {
-		res.DOBMatched = sameYMD(utcDOB, providedDOB.In(time.UTC))
-		if c.strict && !res.DOBMatched {
-			return res, wrapErr(
-				ErrDOBMismatch, tin,
-				"provided DOB does not match encoded date",
-				&utcDOB, providedDOB,
-			)
+// ValidateContext is like Validate but passes ctx to context-aware rules.
+// It returns ctx.Err() if ctx is done before validation finishes.
+func (c *Client) ValidateContext(ctx context.Context, tin string, providedDOB *time.Time) (Result, error) {
+	v := validation{ctx: ctx, client: c, tin: tin, providedDOB: providedDOB}
+	for stage := StagePreNormalize; stage < stageCount; stage++ {
+		if err := ctx.Err(); err != nil {
+			return v.res, err
+		}
+		if err := v.enter(stage); err != nil {
+			return v.res, c.localize(err)
+		}
+		for _, st := range c.pipeline[stage] {
+			if !st.builtin && stage >= StageStructural && len(v.tin) != 10 {
+				// Custom rules may slice the digits; never hand them malformed input.
+				continue
+			}
+			if err := st.run(&v); err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return v.res, ctxErr
+				}
+				err = annotate(err, st.Name, v.tin)
+				if !c.allErrors {
+					return v.res, c.localize(err)
+				}
+				v.collect(err)
+			}
 		}
-	} else {
-		// If strict mode is on but no DOB is given, there is no mismatch.
-		res.DOBMatched = true
 	}
 
-	// TIN is valid only if checksum matches, DOB plausible,
-	// and (if strict, the provided DOB matches).
-	res.Valid = res.ChecksumOK && res.BirthDatePlausible
-	if c.strict && providedDOB != nil {
-		res.Valid = res.Valid && res.DOBMatched
+	// With every enabled rule passed, validity hinges on the checksum.
+	v.res.Valid = len(v.errs) == 0 && (v.res.ChecksumOK || !c.checksumRequired)
+	if len(v.errs) > 0 {
+		return v.res, c.localize(v.errs)
 	}
-	return res, nil
+	return v.res, nil
 }
 
 // --- Rule implementations ---
@@ -267,11 +356,13 @@ func (c *Client) Validate(tin string, providedDOB *time.Time) (Result, error) {
 func ruleLength(n int) Rule[string] {
 	return func(s string) error {
 		if len(s) != n {
-			return wrapErr(
+			e := wrapErr(
 				ErrLength, s,
 				fmt.Sprintf("need %d digits", n),
 				nil, nil,
 			)
+			e.Params = map[string]string{"length": strconv.Itoa(n)}
+			return e
 		}
 		return nil
 	}
@@ -294,10 +385,11 @@ func ruleAllDigits() Rule[string] {
 }
 
 // ruleNotAllSame disallows TINs with all identical digits or all zeros.
+// Empty input is left to the length rule.
 func ruleNotAllSame() Rule[string] {
 	return func(s string) error {
 		if s == "" {
-			return wrapErr(ErrLength, s, "empty", nil, nil)
+			return nil
 		}
 		all := true
 		for i := 1; i < len(s); i++ {
@@ -319,16 +411,7 @@ func ruleNotAllSame() Rule[string] {
 
 // ruleChecksum is kept for external use; it returns ErrChecksum if checksum fails.
 func ruleChecksum() Rule[string] {
-	return func(s string) error {
-		if !ChecksumOK(s) {
-			return wrapErr(
-				ErrChecksum, s,
-				"checksum mismatch",
-				nil, nil,
-			)
-		}
-		return nil
-	}
+	return VerifyChecksum
 }
 
 // --- Helpers ---
@@ -348,20 +431,74 @@ func digitsOnly(s string) string {
 
 // ChecksumOK implements the official RNOKPP checksum using
 // weights [-1,5,7,9,4,6,10,5,7], computing ctrl=((sum mod 11) mod 10).
+// Inputs that are not exactly ten ASCII digits never pass.
 func ChecksumOK(tin string) bool {
 	if len(tin) != 10 {
 		return false
 	}
+	for i := 0; i < len(tin); i++ {
+		if tin[i] < '0' || tin[i] > '9' {
+			return false
+		}
+	}
+	return checkDigit(tin[:9]) == int(tin[9]-'0')
+}
+
+// CheckDigit computes the control digit for a 9-digit prefix.
+func CheckDigit(prefix string) (int, error) {
+	var r Rules[string]
+	r = r.Add(ruleAllDigits(), ruleLength(9))
+	if err := r.Validate(prefix); err != nil {
+		return 0, err
+	}
+	return checkDigit(prefix), nil
+}
+
+// AppendCheckDigit returns the 9-digit prefix with its control digit appended.
+func AppendCheckDigit(prefix string) (string, error) {
+	d, err := CheckDigit(prefix)
+	if err != nil {
+		return "", err
+	}
+	return prefix + strconv.Itoa(d), nil
+}
+
+// VerifyChecksum returns nil if the TIN's control digit is correct.
+// On mismatch it returns an *Error with ErrChecksum whose ExpectedDigit
+// and ActualDigit tell a single typo apart from a made-up number.
+func VerifyChecksum(tin string) error {
+	var r Rules[string]
+	r = r.Add(ruleAllDigits(), ruleLength(10))
+	if err := r.Validate(tin); err != nil {
+		return err
+	}
+	expected, actual := checkDigit(tin[:9]), int(tin[9]-'0')
+	if expected == actual {
+		return nil
+	}
+	e := wrapErr(
+		ErrChecksum, tin,
+		fmt.Sprintf("checksum mismatch: expected %d, got %d", expected, actual),
+		nil, nil,
+	)
+	e.ExpectedDigit = &expected
+	e.ActualDigit = &actual
+	return e
+}
+
+// checkDigit computes the control digit for a 9-digit prefix.
+// The caller must ensure the prefix holds exactly nine ASCII digits.
+func checkDigit(prefix string) int {
 	weights := [...]int{-1, 5, 7, 9, 4, 6, 10, 5, 7}
 	sum := 0
 	for i := 0; i < 9; i++ {
-		sum += int(tin[i]-'0') * weights[i]
+		sum += int(prefix[i]-'0') * weights[i]
 	}
 	ctrl := sum % 11
 	if ctrl < 0 {
 		ctrl += 11
 	}
-	return (ctrl % 10) == int(tin[9]-'0')
+	return ctrl % 10
 }
 
 // DaysToDate converts days since 1899-12-31 to UTC midnight.
@@ -370,6 +507,14 @@ func DaysToDate(days int) time.Time {
 	return base.AddDate(0, 0, days)
 }
 
+// DateToDays converts the calendar date of t (in UTC) to days since 1899-12-31.
+// It is the inverse of DaysToDate.
+func DateToDays(t time.Time) int {
+	y, m, d := t.In(time.UTC).Date()
+	base := time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC)
+	return int((time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() - base.Unix()) / 86400)
+}
+
 // DecodeDOBFromTIN extracts the encoded birth date from a TIN.
 func DecodeDOBFromTIN(tin string) (time.Time, error) {
 	if len(tin) < 5 {
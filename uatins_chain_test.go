@@ -2,6 +2,7 @@ package uatins
 
 import (
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 )
@@ -138,35 +139,84 @@ func TestChainMethodsWithNow(t *testing.T) {
 	functionalClient := NewClient(WithNow(testTime))
 
 	// Both should have the same time set
-	if !chainClient.now.Equal(functionalClient.now) {
-		t.Errorf("Now time mismatch: chain=%s, functional=%s", chainClient.now, functionalClient.now)
+	if !chainClient.clock.Now().Equal(functionalClient.clock.Now()) {
+		t.Errorf("Now time mismatch: chain=%s, functional=%s", chainClient.clock.Now(), functionalClient.clock.Now())
 	}
 
 	// Verify the time is actually used in UTC
 	expectedTime := testTime.In(time.UTC)
-	if !chainClient.now.Equal(expectedTime) {
-		t.Errorf("Now time not converted to UTC: expected=%s, got=%s", expectedTime, chainClient.now)
+	if got := chainClient.clock.Now(); !got.Equal(expectedTime) || got.Location() != time.UTC {
+		t.Errorf("Now time not converted to UTC: expected=%s, got=%s", expectedTime, got)
 	}
 }
 
-// TestChainMethodsReturnValue ensures all chain methods return the client for chaining
+// TestChainMethodsReturnValue ensures chain methods derive a new client and leave the receiver untouched
 func TestChainMethodsReturnValue(t *testing.T) {
 	client := NewClient()
-	
-	// Each method should return the same client instance
-	if client.MaxAge(100) != client {
-		t.Error("MaxAge should return the same client instance")
+
+	// Each method should return a new client instance
+	if client.MaxAge(100) == client {
+		t.Error("MaxAge should return a new client instance")
+	}
+	if client.Strict(true) == client {
+		t.Error("Strict should return a new client instance")
+	}
+	if client.Location(time.Local) == client {
+		t.Error("Location should return a new client instance")
+	}
+	if client.Rules(Rules[string]{}) == client {
+		t.Error("Rules should return a new client instance")
 	}
-	if client.Strict(true) != client {
-		t.Error("Strict should return the same client instance")
+	if client.Now(time.Now()) == client {
+		t.Error("Now should return a new client instance")
 	}
-	if client.Location(time.UTC) != client {
-		t.Error("Location should return the same client instance")
+	if client.Clock(FixedClock(time.Now())) == client {
+		t.Error("Clock should return a new client instance")
+	}
+	if client.Workers(2) == client {
+		t.Error("Workers should return a new client instance")
+	}
+
+	// The base client must keep its defaults
+	if client.maxAgeYears != 130 || client.strict || client.loc != time.UTC || client.custom != nil || client.workers != 0 {
+		t.Errorf("base client was mutated: %+v", client)
 	}
-	if client.Rules(Rules[string]{}) != client {
-		t.Error("Rules should return the same client instance")
+}
+
+// TestChainMethodsRulesCopy ensures a derived client does not alias the caller's rule slice
+func TestChainMethodsRulesCopy(t *testing.T) {
+	fail := Rule[string](func(s string) error { return ErrUnknown })
+	rules := Rules[string]{func(string) error { return nil }}
+	client := NewClient().Rules(rules)
+
+	rules[0] = fail
+	if _, err := client.Validate("3036045681", nil); err != nil {
+		t.Fatalf("derived client saw a later change to the rule slice: %v", err)
 	}
-	if client.Now(time.Now()) != client {
-		t.Error("Now should return the same client instance")
+}
+
+// TestClientConcurrentUse exercises Validate and chain derivations from many goroutines; run with -race
+func TestClientConcurrentUse(t *testing.T) {
+	base := NewClient()
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tenant := base.MaxAge(100 + i).Strict(i%2 == 0).Location(time.UTC)
+			for j := 0; j < 100; j++ {
+				if res, err := base.Validate("3036045681", &dob); err != nil || !res.Valid {
+					t.Errorf("base: unexpected result %+v, %v", res, err)
+					return
+				}
+				if res, err := tenant.Validate("3036045681", &dob); err != nil || !res.Valid {
+					t.Errorf("tenant: unexpected result %+v, %v", res, err)
+					return
+				}
+			}
+		}(i)
 	}
-}
\ No newline at end of file
+	wg.Wait()
+}
@@ -2,6 +2,7 @@ package uatins
 
 import (
 	"errors"
+	"slices"
 	"testing"
 	"time"
 )
@@ -86,3 +87,99 @@ func TestDOBStrictMismatch(t *testing.T) {
 func errorsIs(err error, target error) bool {
 	return err != nil && (err == target || errors.Is(err, target))
 }
+
+func TestCheckDigit(t *testing.T) {
+	d, err := CheckDigit("303604568")
+	if err != nil || d != 1 {
+		t.Fatalf("CheckDigit = %d, %v; want 1", d, err)
+	}
+	tin, err := AppendCheckDigit("303604568")
+	if err != nil || tin != "3036045681" {
+		t.Fatalf("AppendCheckDigit = %q, %v", tin, err)
+	}
+	if _, err := CheckDigit("30360456"); !errorsIs(err, ErrLength) {
+		t.Fatalf("expected ErrLength, got %v", err)
+	}
+	if _, err := CheckDigit("30360456x"); !errorsIs(err, ErrNonDigit) {
+		t.Fatalf("expected ErrNonDigit, got %v", err)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	if err := VerifyChecksum("3036045681"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	err := VerifyChecksum("3036045687")
+	if !errorsIs(err, ErrChecksum) {
+		t.Fatalf("expected ErrChecksum, got %v", err)
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.ExpectedDigit == nil || e.ActualDigit == nil {
+		t.Fatalf("expected digits on error, got %#v", err)
+	}
+	if *e.ExpectedDigit != 1 || *e.ActualDigit != 7 {
+		t.Fatalf("expected 1/7, got %d/%d", *e.ExpectedDigit, *e.ActualDigit)
+	}
+}
+
+func TestRulesValidateAll(t *testing.T) {
+	var r Rules[string]
+	r = r.Add(ruleLength(10), ruleNotAllSame(), ruleChecksum())
+	err := r.ValidateAll("1111")
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %v", err)
+	}
+	if !errors.Is(err, ErrLength) || !errors.Is(err, ErrAllSame) {
+		t.Fatalf("expected ErrLength and ErrAllSame in %v", err)
+	}
+	if err := r.ValidateAll("3036045681"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestAllErrors_Empty(t *testing.T) {
+	for _, in := range []string{"", "abc"} {
+		_, err := NewClient(WithAllErrors(true)).Validate(in, nil)
+		var errs ValidationErrors
+		if !errors.As(err, &errs) || !slices.Equal(errs.Codes(), []ErrorCode{CodeLength}) {
+			t.Fatalf("Validate(%q): expected a single LENGTH, got %v", in, err)
+		}
+	}
+}
+
+func TestAllErrors(t *testing.T) {
+	blocked := errors.New("blocked")
+	client := NewClient(
+		WithAllErrors(true),
+		WithStrict(true),
+		WithNow(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)),
+		WithRules(Rules[string]{func(string) error { return blocked }}),
+	)
+
+	// 1983-02-14 is in the future for this clock, the checksum digit is
+	// wrong, the DOB does not match and the custom rule always fails.
+	dob := time.Date(1983, 2, 15, 0, 0, 0, 0, time.UTC)
+	res, err := client.Validate("3036045687", &dob)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	for _, target := range []error{blocked, ErrBirthOutOfRange, ErrChecksum, ErrDOBMismatch} {
+		if !errors.Is(err, target) {
+			t.Errorf("expected %v in %v", target, err)
+		}
+	}
+	if got := errs.Codes(); len(got) != 4 {
+		t.Errorf("expected 4 codes, got %v", got)
+	}
+	if res.Valid || res.Sex != Female {
+		t.Errorf("expected decoded but invalid result, got %+v", res)
+	}
+
+	// Custom rules and decoding are skipped once the length is wrong.
+	_, err = client.Validate("12", nil)
+	if !errors.As(err, &errs) || len(errs) != 1 || !errors.Is(err, ErrLength) {
+		t.Fatalf("expected only the length failure, got %v", err)
+	}
+}
@@ -30,3 +30,22 @@ func Example_customValidator() {
 	// Output:
 	// valid: false
 }
+
+// Example_resultRule shows a rule written against the decoded Result
+// instead of re-parsing the digits.
+func Example_resultRule() {
+	forbid1999 := uatins.Rule[uatins.Result](func(r uatins.Result) error {
+		if r.BirthDate.Year() == 1999 {
+			return fmt.Errorf("TINs from 1999 are not allowed")
+		}
+		return nil
+	})
+
+	client := uatins.NewClient().
+		ResultRules(uatins.Rules[uatins.Result]{forbid1999})
+
+	_, err := client.Validate("3652412345", nil) // DOB 1999-12-31
+	fmt.Println(err)
+	// Output:
+	// TINs from 1999 are not allowed
+}
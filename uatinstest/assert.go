@@ -0,0 +1,124 @@
+package uatinstest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// Outcome returns the code a validation outcome failed with: the code of
+// err, CodeChecksum for an invalid Result without an error, or "" for a
+// valid Result.
+func Outcome(res uatins.Result, err error) uatins.ErrorCode {
+	switch {
+	case err != nil:
+		return uatins.CodeOf(err)
+	case !res.Valid:
+		return uatins.CodeOf(uatins.VerifyChecksum(res.TIN))
+	}
+	return ""
+}
+
+// codes returns every code in err, or the single Outcome code.
+func codes(res uatins.Result, err error) []uatins.ErrorCode {
+	var errs uatins.ValidationErrors
+	if errors.As(err, &errs) {
+		return errs.Codes()
+	}
+	return []uatins.ErrorCode{Outcome(res, err)}
+}
+
+// AssertValid reports an error unless err is nil and res is valid. It
+// returns whether the assertion held.
+func AssertValid(t testing.TB, res uatins.Result, err error) bool {
+	t.Helper()
+	if err != nil || !res.Valid {
+		t.Errorf("expected a valid TIN, got %+v, err %v", uatins.Reveal(res), uatins.Reveal(err))
+		return false
+	}
+	return true
+}
+
+// AssertInvalid reports an error unless the outcome failed with code. For
+// ValidationErrors any of the collected codes may match.
+func AssertInvalid(t testing.TB, res uatins.Result, err error, code uatins.ErrorCode) bool {
+	t.Helper()
+	got := codes(res, err)
+	if res.Valid || !slices.Contains(got, code) {
+		t.Errorf("expected %s, got codes %v (valid=%v), err %v", code, got, res.Valid, uatins.Reveal(err))
+		return false
+	}
+	return true
+}
+
+// AssertError reports an error unless err holds an *uatins.Error with
+// code, and returns that *Error for further checks (nil on failure).
+func AssertError(t testing.TB, err error, code uatins.ErrorCode) *uatins.Error {
+	t.Helper()
+	for _, e := range flatten(err) {
+		var ue *uatins.Error
+		if errors.As(e, &ue) && ue.Code == code {
+			return ue
+		}
+	}
+	t.Errorf("expected an *uatins.Error with code %s, got %v", code, uatins.Reveal(err))
+	return nil
+}
+
+// flatten returns the entries of ValidationErrors, or err itself.
+func flatten(err error) []error {
+	var errs uatins.ValidationErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	return []error{err}
+}
+
+// AssertResult reports an error for every field in which got differs from
+// want. Birth dates are compared by calendar day; ProvidedDOB is ignored.
+func AssertResult(t testing.TB, got, want uatins.Result) bool {
+	t.Helper()
+	ok := true
+	check := func(field string, g, w any) {
+		if g != w {
+			t.Errorf("Result.%s = %v, want %v", field, g, w)
+			ok = false
+		}
+	}
+	check("TIN", got.TIN, want.TIN)
+	check("BirthDate", day(got.BirthDate), day(want.BirthDate))
+	check("Sex", got.Sex, want.Sex)
+	check("ChecksumOK", got.ChecksumOK, want.ChecksumOK)
+	check("BirthDatePlausible", got.BirthDatePlausible, want.BirthDatePlausible)
+	check("DOBMatched", got.DOBMatched, want.DOBMatched)
+	check("Valid", got.Valid, want.Valid)
+	return ok
+}
+
+// day formats the calendar date of t, or "" for the zero time.
+func day(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// AssertFixture reports an error unless the outcome of validating f
+// matches it: valid or failing with f.Code, with f's birth date and sex.
+func AssertFixture(t testing.TB, f Fixture, res uatins.Result, err error) bool {
+	t.Helper()
+	var ok bool
+	if f.Valid() {
+		ok = AssertValid(t, res, err)
+	} else {
+		ok = AssertInvalid(t, res, err, f.Code)
+	}
+	if !f.BirthDate.IsZero() && (day(res.BirthDate) != day(f.BirthDate) || res.Sex != f.Sex) {
+		t.Errorf("%s: decoded %s %s, want %s %s", f.Name, day(res.BirthDate), res.Sex, day(f.BirthDate), f.Sex)
+		ok = false
+	}
+	return ok
+}
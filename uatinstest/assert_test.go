@@ -0,0 +1,62 @@
+package uatinstest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// recorder captures the failures reported by an assertion helper.
+type recorder struct {
+	testing.TB
+	msgs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.msgs = append(r.msgs, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	client := uatins.NewClient()
+	valid, _ := client.Validate("3036045681", nil)
+	bad, _ := client.Validate("3036045687", nil)
+	_, short := client.Validate("123", nil)
+
+	tests := []struct {
+		name string
+		run  func(testing.TB) bool
+		fail string
+	}{
+		{"valid", func(t testing.TB) bool { return AssertValid(t, valid, nil) }, ""},
+		{"valid fails", func(t testing.TB) bool { return AssertValid(t, bad, nil) }, "3036045687"},
+		{"invalid checksum", func(t testing.TB) bool { return AssertInvalid(t, bad, nil, uatins.CodeChecksum) }, ""},
+		{"invalid wrong code", func(t testing.TB) bool { return AssertInvalid(t, bad, nil, uatins.CodeLength) }, "expected LENGTH"},
+		{"invalid length", func(t testing.TB) bool { return AssertInvalid(t, uatins.Result{}, short, uatins.CodeLength) }, ""},
+		{"error", func(t testing.TB) bool { return AssertError(t, short, uatins.CodeLength) != nil }, ""},
+		{"error nil", func(t testing.TB) bool { return AssertError(t, nil, uatins.CodeLength) != nil }, "got <nil>"},
+		{"result", func(t testing.TB) bool { return AssertResult(t, bad, valid) }, "Result.TIN"},
+	}
+	for _, tt := range tests {
+		r := &recorder{TB: t}
+		ok := tt.run(r)
+		if ok != (tt.fail == "") || (tt.fail != "" && !strings.Contains(strings.Join(r.msgs, "\n"), tt.fail)) {
+			t.Errorf("%s: ok=%v, messages %q", tt.name, ok, r.msgs)
+		}
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	all := uatins.NewClient(uatins.WithAllErrors(true))
+	res, err := all.Validate("3036045687", nil)
+	if got := Outcome(res, err); got != uatins.CodeChecksum {
+		t.Fatalf("Outcome = %s", got)
+	}
+	res, err = all.Validate("3036045681", nil)
+	if got := Outcome(res, err); got != "" {
+		t.Fatalf("Outcome = %s", got)
+	}
+}
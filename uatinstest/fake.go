@@ -0,0 +1,180 @@
+package uatinstest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+var _ uatins.Validator = (*Fake)(nil)
+
+// ErrNotScripted is returned by a Fake for a TIN it has no result for.
+var ErrNotScripted = errors.New("uatinstest: no result scripted for TIN")
+
+// Call records the arguments of one Validate call on a Fake.
+type Call struct {
+	TIN string
+	DOB *time.Time
+}
+
+// outcome is one scripted return value.
+type outcome struct {
+	res uatins.Result
+	err error
+}
+
+// Fake is a deterministic uatins.Validator that returns the results
+// scripted for each TIN instead of validating. TINs are matched on their
+// digits, so "3036-045-681" finds a script for "3036045681".
+//
+// A Fake is safe for concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	scripts map[string][]outcome
+	calls   []Call
+}
+
+// NewFake returns a Fake with nothing scripted.
+func NewFake() *Fake {
+	return &Fake{scripts: make(map[string][]outcome)}
+}
+
+// Return scripts res and err for tin. Calling Return again for the same
+// TIN queues another outcome: calls consume the queue in order and the
+// last outcome repeats once it is reached.
+func (f *Fake) Return(tin string, res uatins.Result, err error) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := digits(tin)
+	f.scripts[key] = append(f.scripts[key], outcome{res, err})
+	return f
+}
+
+// Pass scripts a valid Result for tin with its encoded birth date and sex.
+func (f *Fake) Pass(tin string) *Fake {
+	res := decode(tin)
+	res.ChecksumOK = true
+	res.BirthDatePlausible = true
+	res.DOBMatched = true
+	res.Valid = true
+	return f.Return(tin, res, nil)
+}
+
+// Fail scripts an invalid Result for tin failing with code. Like a real
+// client, CodeChecksum yields a nil error and ChecksumOK false; any other
+// code yields an *uatins.Error.
+func (f *Fake) Fail(tin string, code uatins.ErrorCode) *Fake {
+	res := decode(tin)
+	if code == uatins.CodeChecksum {
+		return f.Return(tin, res, nil)
+	}
+	res.ChecksumOK = uatins.ChecksumOK(res.TIN)
+	return f.Return(tin, res, &uatins.Error{Code: code, TIN: res.TIN})
+}
+
+// Validate returns the next outcome scripted for tin, or ErrNotScripted.
+func (f *Fake) Validate(tin string, providedDOB *time.Time) (uatins.Result, error) {
+	return f.ValidateContext(context.Background(), tin, providedDOB)
+}
+
+// ValidateContext is like Validate but returns ctx.Err() if ctx is done.
+// Such calls are recorded but do not consume a scripted outcome.
+func (f *Fake) ValidateContext(ctx context.Context, tin string, providedDOB *time.Time) (uatins.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{TIN: tin, DOB: providedDOB})
+	if err := ctx.Err(); err != nil {
+		return uatins.Result{}, err
+	}
+
+	key := digits(tin)
+	queue := f.scripts[key]
+	if len(queue) == 0 {
+		return uatins.Result{TIN: key}, fmt.Errorf("%w %s", ErrNotScripted, tin)
+	}
+	out := queue[0]
+	if len(queue) > 1 {
+		f.scripts[key] = queue[1:]
+	}
+	out.res.ProvidedDOB = providedDOB
+	return out.res, out.err
+}
+
+// ValidateBatch calls ValidateContext for each item in order. Once ctx is
+// done the remaining items get ctx.Err(), which is also returned.
+func (f *Fake) ValidateBatch(ctx context.Context, items []uatins.BatchItem) ([]uatins.BatchResult, error) {
+	out := make([]uatins.BatchResult, len(items))
+	for i, item := range items {
+		out[i].Index = i
+		out[i].Result, out[i].Err = f.ValidateContext(ctx, item.TIN, item.DOB)
+	}
+	return out, ctx.Err()
+}
+
+// ValidateStream calls ValidateContext for each item read from in, one at
+// a time, so results arrive in input order. The returned channel is closed
+// once in is drained or ctx is done.
+func (f *Fake) ValidateStream(ctx context.Context, in <-chan uatins.BatchItem) <-chan uatins.BatchResult {
+	out := make(chan uatins.BatchResult)
+	go func() {
+		defer close(out)
+		for i := 0; ; i++ {
+			var item uatins.BatchItem
+			select {
+			case <-ctx.Done():
+				return
+			case it, ok := <-in:
+				if !ok {
+					return
+				}
+				item = it
+			}
+			r := uatins.BatchResult{Index: i}
+			r.Result, r.Err = f.ValidateContext(ctx, item.TIN, item.DOB)
+			select {
+			case <-ctx.Done():
+				return
+			case out <- r:
+			}
+		}
+	}()
+	return out
+}
+
+// Calls returns the calls made so far, in order. Each item of a batch is
+// recorded as one call, as is each streamed item.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// digits strips everything but ASCII digits, as Validate does.
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// decode returns a Result holding tin's digits and, for ten of them, the
+// encoded birth date and sex.
+func decode(tin string) uatins.Result {
+	res := uatins.Result{TIN: digits(tin)}
+	if len(res.TIN) != 10 {
+		return res
+	}
+	res.BirthDate, _ = uatins.DecodeDOBFromTIN(res.TIN)
+	res.Sex = uatins.Female
+	if (res.TIN[8]-'0')%2 == 1 {
+		res.Sex = uatins.Male
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+package uatinstest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+func TestFake(t *testing.T) {
+	f := NewFake().
+		Pass("3036045681").
+		Fail("3036045681", uatins.CodeDOBMismatch).
+		Fail("3036045687", uatins.CodeChecksum)
+
+	res, err := f.Validate("3036-045-681", nil)
+	AssertValid(t, res, err)
+	AssertResult(t, res, uatins.Result{
+		TIN:                "3036045681",
+		BirthDate:          time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC),
+		Sex:                uatins.Female,
+		ChecksumOK:         true,
+		BirthDatePlausible: true,
+		DOBMatched:         true,
+		Valid:              true,
+	})
+
+	// The second outcome is returned from then on.
+	for i := 0; i < 2; i++ {
+		res, err = f.Validate("3036045681", nil)
+		if e := AssertError(t, err, uatins.CodeDOBMismatch); e != nil && !errors.Is(e, uatins.ErrDOBMismatch) {
+			t.Fatalf("error does not match its sentinel: %v", e)
+		}
+	}
+
+	res, err = f.Validate("3036045687", nil)
+	AssertInvalid(t, res, err, uatins.CodeChecksum)
+	if err != nil || res.ChecksumOK {
+		t.Fatalf("checksum failures have no error: %+v, %v", uatins.Reveal(res), err)
+	}
+
+	if _, err := f.Validate("1234567890", nil); !errors.Is(err, ErrNotScripted) {
+		t.Fatalf("expected ErrNotScripted, got %v", err)
+	}
+}
+
+func TestFake_Calls(t *testing.T) {
+	f := NewFake().Return("3036045681", uatins.Result{TIN: "3036045681"}, nil)
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+
+	res, _ := f.Validate("3036045681", &dob)
+	if res.ProvidedDOB != &dob {
+		t.Fatal("ProvidedDOB must be the argument")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.ValidateContext(ctx, "3036045681", nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	calls := f.Calls()
+	if len(calls) != 2 || calls[0].DOB != &dob || calls[1].TIN != "3036045681" {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+}
+
+func TestFake_ValidateBatch(t *testing.T) {
+	var v uatins.Validator = NewFake().Pass("3036045681").Fail("1111111111", uatins.CodeAllSame)
+	results, err := v.ValidateBatch(context.Background(), []uatins.BatchItem{{TIN: "3036045681"}, {TIN: "1111111111"}})
+	if err != nil || len(results) != 2 {
+		t.Fatalf("ValidateBatch: %v, %v", results, err)
+	}
+	AssertValid(t, results[0].Result, results[0].Err)
+	AssertInvalid(t, results[1].Result, results[1].Err, uatins.CodeAllSame)
+
+	// Decorators accept the fake like any other Validator.
+	cached := uatins.Cached(v, 10, 0)
+	cached.Validate("3036045681", nil)
+	cached.Validate("3036045681", nil)
+	if n := len(v.(*Fake).Calls()); n != 3 {
+		t.Fatalf("fake called %d times, want 3", n)
+	}
+}
+
+func TestFake_ValidateStream(t *testing.T) {
+	f := NewFake().Pass("3036045681").Fail("1111111111", uatins.CodeAllSame)
+	in := make(chan uatins.BatchItem, 2)
+	in <- uatins.BatchItem{TIN: "3036045681"}
+	in <- uatins.BatchItem{TIN: "1111111111"}
+	close(in)
+
+	var results []uatins.BatchResult
+	for r := range f.ValidateStream(context.Background(), in) {
+		results = append(results, r)
+	}
+	if len(results) != 2 || results[0].Index != 0 || results[1].Index != 1 {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	AssertValid(t, results[0].Result, results[0].Err)
+	AssertInvalid(t, results[1].Result, results[1].Err, uatins.CodeAllSame)
+}
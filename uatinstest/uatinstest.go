@@ -0,0 +1,159 @@
+// Package uatinstest provides fixtures, a scripted fake validator and
+// assertion helpers for tests of code built on uatins.
+//
+// Fixtures are TINs paired with the outcome a strict client reports for
+// them, so table tests need no hand-rolled checksum loops:
+//
+//	client := uatins.NewClient(uatins.WithStrict(true))
+//	for _, f := range uatinstest.All() {
+//		t.Run(f.Name, func(t *testing.T) {
+//			res, err := client.Validate(f.TIN, f.DOB)
+//			uatinstest.AssertFixture(t, f, res, err)
+//		})
+//	}
+//
+// Fake is a uatins.Validator for code under test that should see scripted
+// results instead of real validation.
+package uatinstest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+// Fixture is a TIN with the outcome of validating it with a client built
+// WithStrict(true).
+type Fixture struct {
+	Name string
+	TIN  string
+	// DOB is the birth date to pass to Validate; nil for none.
+	DOB *time.Time
+	// BirthDate and Sex are the values encoded in TIN. They are zero for
+	// inputs that fail before decoding.
+	BirthDate time.Time
+	Sex       uatins.Sex
+	// Code is the failure the fixture exercises, empty for a valid TIN.
+	// A CodeChecksum fixture yields an invalid Result and a nil error
+	// unless the client is built WithAllErrors.
+	Code uatins.ErrorCode
+}
+
+// Valid reports whether the fixture holds a valid TIN.
+func (f Fixture) Valid() bool {
+	return f.Code == ""
+}
+
+// seed makes TIN deterministic.
+const seed = 1983
+
+// TIN returns a TIN with a valid checksum encoding the calendar date of
+// dob (in UTC) and sex; any sex but uatins.Male gives a female TIN. It is
+// made by a uatins.Generator with a fixed seed, so the same arguments
+// always give the same TIN. It panics if dob is not between 1900-01-01 and
+// 2173-10-14.
+func TIN(dob time.Time, sex uatins.Sex) string {
+	if sex != uatins.Male {
+		sex = uatins.Female
+	}
+	tin, err := uatins.NewGenerator(uatins.WithSeed(seed)).Generate(dob, sex)
+	if err != nil {
+		panic(fmt.Sprintf("uatinstest: birth date %s cannot be encoded", dob.Format("2006-01-02")))
+	}
+	return tin
+}
+
+// withSerial returns the TIN with the given day count (0..99999) and
+// serial. Unlike TIN it accepts day 0, which decodes to 1899-12-31.
+func withSerial(days, serial int) string {
+	tin, err := uatins.AppendCheckDigit(fmt.Sprintf("%05d%04d", days, serial))
+	if err != nil {
+		panic(err)
+	}
+	return tin
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// valid returns the fixture for a valid TIN of the given date and sex,
+// with the date passed as DOB.
+func valid(name string, dob time.Time, sex uatins.Sex) Fixture {
+	return Fixture{Name: name, TIN: TIN(dob, sex), DOB: &dob, BirthDate: dob, Sex: sex}
+}
+
+// Valid returns valid TINs of both sexes, including the earliest encodable
+// birth date (1900-01-01) and a leap day.
+func Valid() []Fixture {
+	dob := date(1983, 2, 14)
+	return []Fixture{
+		{Name: "female", TIN: "3036045681", DOB: &dob, BirthDate: dob, Sex: uatins.Female},
+		valid("male", dob, uatins.Male),
+		{Name: "formatted", TIN: "3036-045-681", BirthDate: dob, Sex: uatins.Female},
+		valid("female 1900-01-01", date(1900, 1, 1), uatins.Female),
+		valid("male 1900-01-01", date(1900, 1, 1), uatins.Male),
+		valid("female leap day", date(2000, 2, 29), uatins.Female),
+		valid("male leap day", date(2000, 2, 29), uatins.Male),
+	}
+}
+
+// Male returns the valid fixtures of male TINs.
+func Male() []Fixture {
+	return bySex(uatins.Male)
+}
+
+// Female returns the valid fixtures of female TINs.
+func Female() []Fixture {
+	return bySex(uatins.Female)
+}
+
+func bySex(sex uatins.Sex) []Fixture {
+	var out []Fixture
+	for _, f := range Valid() {
+		if f.Sex == sex {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Invalid returns one or more fixtures for each error class Validate
+// reports. There is none for CodeNonDigit: Validate strips non-digits, so
+// such input fails with CodeLength.
+func Invalid() []Fixture {
+	dob := date(1983, 2, 14)
+	wrong := dob.AddDate(0, 0, 1)
+	return []Fixture{
+		{Name: "too short", TIN: "303604568", Code: uatins.CodeLength},
+		{Name: "too long", TIN: "30360456811", Code: uatins.CodeLength},
+		{Name: "letters", TIN: "30360456a1", Code: uatins.CodeLength},
+		{Name: "all same", TIN: "1111111111", Code: uatins.CodeAllSame},
+		{Name: "all zero", TIN: "0000000000", Code: uatins.CodeAllSame},
+		{Name: "checksum", TIN: "3036045687", BirthDate: dob, Sex: uatins.Female, Code: uatins.CodeChecksum},
+		{Name: "before 1900", TIN: withSerial(0, 4568), BirthDate: date(1899, 12, 31), Sex: uatins.Female, Code: uatins.CodeBirthOutOfRange},
+		{Name: "far future", TIN: withSerial(99999, 4567), BirthDate: uatins.DaysToDate(99999), Sex: uatins.Male, Code: uatins.CodeBirthOutOfRange},
+		{Name: "dob mismatch", TIN: "3036045681", DOB: &wrong, BirthDate: dob, Sex: uatins.Female, Code: uatins.CodeDOBMismatch},
+	}
+}
+
+// Boundaries returns TINs born on the day of now, which are valid, and on
+// the day after, which are not. The client must read now from its clock:
+// uatins.WithClock(uatins.FixedClock(now)).
+func Boundaries(now time.Time) []Fixture {
+	y, m, d := now.In(time.UTC).Date()
+	today := date(y, m, d)
+	tomorrow := today.AddDate(0, 0, 1)
+	return []Fixture{
+		valid("female today", today, uatins.Female),
+		valid("male today", today, uatins.Male),
+		{Name: "tomorrow", TIN: TIN(tomorrow, uatins.Male), BirthDate: tomorrow, Sex: uatins.Male, Code: uatins.CodeBirthOutOfRange},
+	}
+}
+
+// All returns Valid, Invalid and Boundaries for the current time.
+func All() []Fixture {
+	out := append(Valid(), Invalid()...)
+	return append(out, Boundaries(time.Now())...)
+}
@@ -0,0 +1,67 @@
+package uatinstest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/uatins"
+)
+
+func TestFixtures(t *testing.T) {
+	now := time.Date(2025, 6, 30, 23, 59, 0, 0, time.UTC)
+	fixtures := append(Valid(), Invalid()...)
+	fixtures = append(fixtures, Boundaries(now)...)
+
+	for _, allErrors := range []bool{false, true} {
+		client := uatins.NewClient(uatins.WithStrict(true), uatins.WithClock(uatins.FixedClock(now)), uatins.WithAllErrors(allErrors))
+		for _, f := range fixtures {
+			res, err := client.Validate(f.TIN, f.DOB)
+			if !AssertFixture(t, f, res, err) {
+				t.Logf("fixture %q, all errors %v", f.Name, allErrors)
+			}
+		}
+	}
+}
+
+func TestFixtures_Sets(t *testing.T) {
+	if len(Male()) == 0 || len(Female()) == 0 || len(Male())+len(Female()) != len(Valid()) {
+		t.Fatalf("Male and Female must split Valid")
+	}
+	for _, f := range Male() {
+		if f.Sex != uatins.Male || !f.Valid() {
+			t.Errorf("unexpected male fixture %+v", f)
+		}
+	}
+	seen := make(map[uatins.ErrorCode]bool)
+	for _, f := range Invalid() {
+		seen[f.Code] = true
+	}
+	for _, code := range []uatins.ErrorCode{uatins.CodeLength, uatins.CodeAllSame, uatins.CodeChecksum, uatins.CodeBirthOutOfRange, uatins.CodeDOBMismatch} {
+		if !seen[code] {
+			t.Errorf("no fixture for %s", code)
+		}
+	}
+	if len(All()) != len(Valid())+len(Invalid())+3 {
+		t.Errorf("All() has %d fixtures", len(All()))
+	}
+}
+
+func TestTIN(t *testing.T) {
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	want := uatins.NewGenerator(uatins.WithSeed(seed)).MustGenerate(dob, uatins.Female)
+	if got := TIN(dob, uatins.Female); got != want {
+		t.Fatalf("TIN = %s, want %s", got, want)
+	}
+	if TIN(dob, "") != want {
+		t.Fatal("an empty sex must give a female TIN")
+	}
+	if TIN(dob, uatins.Male) != TIN(dob, uatins.Male) {
+		t.Fatal("TIN must be deterministic")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for a date before 1900")
+		}
+	}()
+	TIN(time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC), uatins.Male)
+}
@@ -0,0 +1,407 @@
+package uatins
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Validator validates TINs. *Client implements it; Cached, Logged and
+// Measured wrap any Validator, and code that only validates can accept a
+// Validator so tests can substitute a fake. Implementations follow the
+// contracts of the Client methods of the same names.
+type Validator interface {
+	Validate(tin string, providedDOB *time.Time) (Result, error)
+	ValidateContext(ctx context.Context, tin string, providedDOB *time.Time) (Result, error)
+	ValidateBatch(ctx context.Context, items []BatchItem) ([]BatchResult, error)
+	ValidateStream(ctx context.Context, in <-chan BatchItem) <-chan BatchResult
+}
+
+var _ Validator = (*Client)(nil)
+
+// outcomeCode returns the code a validation failed with: the code of err,
+// CodeChecksum for an invalid Result without an error, or "" if valid.
+func outcomeCode(res Result, err error) ErrorCode {
+	switch {
+	case err != nil:
+		return CodeOf(err)
+	case !res.Valid:
+		return CodeChecksum
+	}
+	return ""
+}
+
+// transient reports whether err depends on the call rather than the input.
+func transient(err error) bool {
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrRuleTimeout)
+}
+
+// sendResult delivers r on out unless ctx is done first.
+func sendResult(ctx context.Context, out chan<- BatchResult, r BatchResult) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- r:
+		return true
+	}
+}
+
+// --- Caching ---
+
+// Cached returns a Validator that remembers up to size outcomes of v,
+// keyed by the input and the provided birth date, for at most ttl (0 keeps
+// them until evicted). Outcomes caused by cancellation or a rule timeout
+// are not cached. Since plausibility depends on the clock and custom rules
+// may consult external data, pick ttl accordingly.
+func Cached(v Validator, size int, ttl time.Duration) Validator {
+	return &cachedValidator{
+		next:  v,
+		size:  max(size, 1),
+		ttl:   ttl,
+		now:   time.Now,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+type cachedValidator struct {
+	next Validator
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List // most recently used first
+}
+
+type cacheEntry struct {
+	key     string
+	res     Result
+	err     error
+	expires time.Time
+}
+
+func cacheKey(tin string, dob *time.Time) string {
+	if dob == nil {
+		return tin
+	}
+	return tin + "\x00" + dob.UTC().Format(time.RFC3339Nano)
+}
+
+func (c *cachedValidator) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	e := el.Value.(*cacheEntry)
+	if c.ttl > 0 && !c.now().Before(e.expires) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return cacheEntry{}, false
+	}
+	c.order.MoveToFront(el)
+	return *e, true
+}
+
+func (c *cachedValidator) put(key string, res Result, err error) {
+	if transient(err) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &cacheEntry{key: key, res: res, err: err, expires: c.now().Add(c.ttl)}
+	if el, ok := c.items[key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(e)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *cachedValidator) Validate(tin string, providedDOB *time.Time) (Result, error) {
+	return c.ValidateContext(context.Background(), tin, providedDOB)
+}
+
+func (c *cachedValidator) ValidateContext(ctx context.Context, tin string, providedDOB *time.Time) (Result, error) {
+	key := cacheKey(tin, providedDOB)
+	if e, ok := c.get(key); ok {
+		e.res.ProvidedDOB = providedDOB
+		return e.res, e.err
+	}
+	res, err := c.next.ValidateContext(ctx, tin, providedDOB)
+	c.put(key, res, err)
+	return res, err
+}
+
+// ValidateBatch answers cached items directly and validates the rest as
+// one batch of the wrapped Validator.
+func (c *cachedValidator) ValidateBatch(ctx context.Context, items []BatchItem) ([]BatchResult, error) {
+	out := make([]BatchResult, len(items))
+	var misses []BatchItem
+	var missIdx []int
+	for i, item := range items {
+		out[i].Index = i
+		if e, ok := c.get(cacheKey(item.TIN, item.DOB)); ok {
+			e.res.ProvidedDOB = item.DOB
+			out[i].Result, out[i].Err = e.res, e.err
+			continue
+		}
+		misses = append(misses, item)
+		missIdx = append(missIdx, i)
+	}
+	if len(misses) == 0 {
+		return out, nil
+	}
+
+	results, err := c.next.ValidateBatch(ctx, misses)
+	for j, r := range results {
+		i := missIdx[j]
+		out[i].Result, out[i].Err = r.Result, r.Err
+		c.put(cacheKey(items[i].TIN, items[i].DOB), r.Result, r.Err)
+	}
+	return out, err
+}
+
+// ValidateStream answers cached items directly and streams the rest
+// through the wrapped Validator. Index keeps referring to the position in
+// in; results may arrive out of order.
+func (c *cachedValidator) ValidateStream(ctx context.Context, in <-chan BatchItem) <-chan BatchResult {
+	type miss struct {
+		index int
+		key   string
+	}
+	var (
+		mu      sync.Mutex
+		pending = make(map[int]miss) // by position in the wrapped stream
+		out     = make(chan BatchResult)
+		misses  = make(chan BatchItem)
+		wg      sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer close(misses)
+		for i, n := 0, 0; ; i++ {
+			var item BatchItem
+			select {
+			case <-ctx.Done():
+				return
+			case it, ok := <-in:
+				if !ok {
+					return
+				}
+				item = it
+			}
+			key := cacheKey(item.TIN, item.DOB)
+			if e, ok := c.get(key); ok {
+				e.res.ProvidedDOB = item.DOB
+				if !sendResult(ctx, out, BatchResult{Index: i, Result: e.res, Err: e.err}) {
+					return
+				}
+				continue
+			}
+			mu.Lock()
+			pending[n] = miss{i, key}
+			mu.Unlock()
+			n++
+			select {
+			case <-ctx.Done():
+				return
+			case misses <- item:
+			}
+		}
+	}()
+
+	results := c.next.ValidateStream(ctx, misses)
+	go func() {
+		defer wg.Done()
+		for r := range results {
+			mu.Lock()
+			m := pending[r.Index]
+			delete(pending, r.Index)
+			mu.Unlock()
+			c.put(m.key, r.Result, r.Err)
+			r.Index = m.index
+			if !sendResult(ctx, out, r) {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// --- Logging ---
+
+// Logged returns a Validator that logs every outcome of v to logger:
+// valid TINs at Debug, invalid ones at Info and cancelled calls at Warn.
+// TINs are masked as described for SetMaskStyle.
+func Logged(v Validator, logger *slog.Logger) Validator {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &loggedValidator{next: v, log: logger}
+}
+
+type loggedValidator struct {
+	next Validator
+	log  *slog.Logger
+}
+
+func (l *loggedValidator) Validate(tin string, providedDOB *time.Time) (Result, error) {
+	return l.ValidateContext(context.Background(), tin, providedDOB)
+}
+
+func (l *loggedValidator) ValidateContext(ctx context.Context, tin string, providedDOB *time.Time) (Result, error) {
+	start := time.Now()
+	res, err := l.next.ValidateContext(ctx, tin, providedDOB)
+	l.record(ctx, res, err, slog.Duration("duration", time.Since(start)))
+	return res, err
+}
+
+func (l *loggedValidator) ValidateBatch(ctx context.Context, items []BatchItem) ([]BatchResult, error) {
+	start := time.Now()
+	results, err := l.next.ValidateBatch(ctx, items)
+	for _, r := range results {
+		l.record(ctx, r.Result, r.Err, slog.Int("index", r.Index))
+	}
+	l.log.LogAttrs(ctx, slog.LevelDebug, "tin batch validated",
+		slog.Int("items", len(items)), slog.Duration("duration", time.Since(start)))
+	return results, err
+}
+
+func (l *loggedValidator) ValidateStream(ctx context.Context, in <-chan BatchItem) <-chan BatchResult {
+	start := time.Now()
+	results := l.next.ValidateStream(ctx, in)
+	out := make(chan BatchResult)
+	go func() {
+		defer close(out)
+		n := 0
+		defer func() {
+			l.log.LogAttrs(ctx, slog.LevelDebug, "tin stream validated",
+				slog.Int("items", n), slog.Duration("duration", time.Since(start)))
+		}()
+		for r := range results {
+			n++
+			l.record(ctx, r.Result, r.Err, slog.Int("index", r.Index))
+			if !sendResult(ctx, out, r) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (l *loggedValidator) record(ctx context.Context, res Result, err error, extra slog.Attr) {
+	level := slog.LevelDebug
+	switch {
+	case transient(err):
+		level = slog.LevelWarn
+	case err != nil || !res.Valid:
+		level = slog.LevelInfo
+	}
+	if !l.log.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{slog.Any("result", res), extra}
+	if code := outcomeCode(res, err); code != "" {
+		attrs = append(attrs, slog.String("code", string(code)))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("err", err))
+	}
+	l.log.LogAttrs(ctx, level, "tin validated", attrs...)
+}
+
+// --- Metrics ---
+
+// Metrics receives the measurements taken by Measured. Implementations
+// typically forward them to counters and histograms of a metrics library
+// and must be safe for concurrent use.
+type Metrics interface {
+	// CountOutcome is called once per validated TIN with the code it
+	// failed with, or "" if it is valid. TINs whose validation was
+	// cancelled or hit a rule timeout are not counted.
+	CountOutcome(code ErrorCode)
+	// ObserveLatency is called once per call, or once a stream ends, with
+	// the number of TINs validated and the time taken.
+	ObserveLatency(items int, d time.Duration)
+}
+
+// Measured returns a Validator that reports every outcome and call
+// latency of v to m.
+func Measured(v Validator, m Metrics) Validator {
+	return &measuredValidator{next: v, m: m}
+}
+
+type measuredValidator struct {
+	next Validator
+	m    Metrics
+}
+
+func (mv *measuredValidator) Validate(tin string, providedDOB *time.Time) (Result, error) {
+	return mv.ValidateContext(context.Background(), tin, providedDOB)
+}
+
+func (mv *measuredValidator) ValidateContext(ctx context.Context, tin string, providedDOB *time.Time) (Result, error) {
+	start := time.Now()
+	res, err := mv.next.ValidateContext(ctx, tin, providedDOB)
+	mv.m.ObserveLatency(1, time.Since(start))
+	mv.count(res, err)
+	return res, err
+}
+
+func (mv *measuredValidator) ValidateBatch(ctx context.Context, items []BatchItem) ([]BatchResult, error) {
+	start := time.Now()
+	results, err := mv.next.ValidateBatch(ctx, items)
+	mv.m.ObserveLatency(len(items), time.Since(start))
+	for _, r := range results {
+		mv.count(r.Result, r.Err)
+	}
+	return results, err
+}
+
+// count reports the outcome of one TIN unless it depends on the call.
+func (mv *measuredValidator) count(res Result, err error) {
+	if !transient(err) {
+		mv.m.CountOutcome(outcomeCode(res, err))
+	}
+}
+
+// ValidateStream reports every outcome as it arrives and the latency of
+// the whole stream once it ends.
+func (mv *measuredValidator) ValidateStream(ctx context.Context, in <-chan BatchItem) <-chan BatchResult {
+	start := time.Now()
+	results := mv.next.ValidateStream(ctx, in)
+	out := make(chan BatchResult)
+	go func() {
+		defer close(out)
+		n := 0
+		defer func() { mv.m.ObserveLatency(n, time.Since(start)) }()
+		for r := range results {
+			n++
+			mv.count(r.Result, r.Err)
+			if !sendResult(ctx, out, r) {
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,245 @@
+package uatins
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingValidator counts the TINs that reach the wrapped client.
+type countingValidator struct {
+	*Client
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingValidator) ValidateContext(ctx context.Context, tin string, dob *time.Time) (Result, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+	return c.Client.ValidateContext(ctx, tin, dob)
+}
+
+func (c *countingValidator) ValidateBatch(ctx context.Context, items []BatchItem) ([]BatchResult, error) {
+	c.mu.Lock()
+	c.calls += len(items)
+	c.mu.Unlock()
+	return c.Client.ValidateBatch(ctx, items)
+}
+
+func (c *countingValidator) ValidateStream(ctx context.Context, in <-chan BatchItem) <-chan BatchResult {
+	counted := make(chan BatchItem)
+	go func() {
+		defer close(counted)
+		for item := range in {
+			c.mu.Lock()
+			c.calls++
+			c.mu.Unlock()
+			counted <- item
+		}
+	}()
+	return c.Client.ValidateStream(ctx, counted)
+}
+
+// stream runs items through v.ValidateStream and returns the results in
+// input order.
+func stream(t *testing.T, v Validator, items []BatchItem) []BatchResult {
+	t.Helper()
+	in := make(chan BatchItem)
+	go func() {
+		defer close(in)
+		for _, item := range items {
+			in <- item
+		}
+	}()
+	out := make([]BatchResult, len(items))
+	n := 0
+	for r := range v.ValidateStream(context.Background(), in) {
+		out[r.Index] = r
+		n++
+	}
+	if n != len(items) {
+		t.Fatalf("got %d results for %d items", n, len(items))
+	}
+	return out
+}
+
+func TestCached(t *testing.T) {
+	inner := &countingValidator{Client: NewClient(WithStrict(true))}
+	v := Cached(inner, 2, time.Minute)
+	now := time.Now()
+	v.(*cachedValidator).now = func() time.Time { return now }
+
+	dob := time.Date(1983, 2, 14, 0, 0, 0, 0, time.UTC)
+	wrong := dob.AddDate(0, 0, 1)
+	for i := 0; i < 3; i++ {
+		if res, err := v.Validate("3036045681", &dob); err != nil || !res.Valid || res.ProvidedDOB != &dob {
+			t.Fatalf("Validate: %+v, %v", res, err)
+		}
+	}
+	// The provided DOB is part of the key, and errors are cached too.
+	for i := 0; i < 2; i++ {
+		if _, err := v.Validate("3036045681", &wrong); !errorsIs(err, ErrDOBMismatch) {
+			t.Fatalf("expected ErrDOBMismatch, got %v", err)
+		}
+	}
+	if inner.calls != 2 {
+		t.Fatalf("inner called %d times, want 2", inner.calls)
+	}
+
+	// A third key evicts the least recently used one.
+	v.Validate("3036045687", nil)
+	v.Validate("3036045681", &dob)
+	if inner.calls != 4 {
+		t.Fatalf("inner called %d times, want 4", inner.calls)
+	}
+
+	now = now.Add(time.Minute)
+	v.Validate("3036045681", &dob)
+	if inner.calls != 5 {
+		t.Fatalf("expired entry reused: %d calls", inner.calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	v.ValidateContext(ctx, "2923069874", nil)
+	v.ValidateContext(ctx, "2923069874", nil)
+	if inner.calls != 7 {
+		t.Fatalf("cancelled outcome was cached: %d calls", inner.calls)
+	}
+}
+
+func TestCached_Batch(t *testing.T) {
+	inner := &countingValidator{Client: NewClient()}
+	v := Cached(inner, 10, 0)
+	v.Validate("3036045681", nil)
+
+	items := []BatchItem{{TIN: "3036045681"}, {TIN: "3036045687"}, {TIN: "12"}, {TIN: "3036045687"}}
+	results, err := v.ValidateBatch(context.Background(), items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 4 {
+		t.Fatalf("inner called %d times, want 4", inner.calls)
+	}
+	for i, r := range results {
+		want, wantErr := NewClient().Validate(items[i].TIN, nil)
+		if r.Index != i || r.Result.Valid != want.Valid || CodeOf(r.Err) != CodeOf(wantErr) {
+			t.Errorf("result %d = %+v, want %+v", i, r, want)
+		}
+	}
+	v.ValidateBatch(context.Background(), items)
+	if inner.calls != 4 {
+		t.Fatalf("second batch was not served from cache: %d calls", inner.calls)
+	}
+}
+
+func TestCached_Stream(t *testing.T) {
+	inner := &countingValidator{Client: NewClient(WithWorkers(3))}
+	v := Cached(inner, 10, 0)
+	v.Validate("3036045681", nil)
+
+	items := []BatchItem{{TIN: "12"}, {TIN: "3036045681"}, {TIN: "3036045687"}, {TIN: "1111111111"}}
+	for round := 0; round < 2; round++ {
+		for i, r := range stream(t, v, items) {
+			want, wantErr := NewClient().Validate(items[i].TIN, nil)
+			if r.Result.TIN != want.TIN || r.Result.Valid != want.Valid || CodeOf(r.Err) != CodeOf(wantErr) {
+				t.Errorf("round %d, item %d = %+v, want %+v", round, i, r, want)
+			}
+		}
+	}
+	if inner.calls != 4 {
+		t.Fatalf("inner called %d times, want 4", inner.calls)
+	}
+}
+
+func TestLogged(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	v := Logged(NewClient(), log)
+
+	v.Validate("3036045681", nil)
+	if buf.Len() != 0 {
+		t.Fatalf("valid TIN logged at Info: %s", buf.String())
+	}
+	v.Validate("3036045687", nil)
+	v.ValidateBatch(context.Background(), []BatchItem{{TIN: "12"}})
+	got := buf.String()
+	if strings.Contains(got, "3036045687") || !strings.Contains(got, "result.tin=30******87") || !strings.Contains(got, "code=CHECKSUM") {
+		t.Fatalf("unexpected log: %s", got)
+	}
+	if !strings.Contains(got, "index=0") || !strings.Contains(got, "code=LENGTH") {
+		t.Fatalf("batch item not logged: %s", got)
+	}
+
+	buf.Reset()
+	stream(t, v, []BatchItem{{TIN: "3036045681"}, {TIN: "1111111111"}})
+	if got := buf.String(); strings.Count(got, "tin validated") != 1 || !strings.Contains(got, "code=ALL_SAME") {
+		t.Fatalf("unexpected stream log: %s", got)
+	}
+}
+
+// recordingMetrics stores what Measured reports.
+type recordingMetrics struct {
+	mu       sync.Mutex
+	outcomes map[ErrorCode]int
+	items    int
+	calls    int
+}
+
+func (m *recordingMetrics) CountOutcome(code ErrorCode) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.outcomes[code]++
+}
+
+func (m *recordingMetrics) ObserveLatency(items int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	m.items += items
+}
+
+func TestMeasured(t *testing.T) {
+	m := &recordingMetrics{outcomes: make(map[ErrorCode]int)}
+	v := Measured(NewClient(), m)
+
+	v.Validate("3036045681", nil)
+	v.Validate("3036045687", nil)
+	v.ValidateBatch(context.Background(), []BatchItem{{TIN: "12"}, {TIN: "1111111111"}, {TIN: "3036045681"}})
+
+	stream(t, v, []BatchItem{{TIN: "3036045681"}, {TIN: "12"}})
+
+	if m.calls != 4 || m.items != 7 {
+		t.Fatalf("latency observed %d times for %d items", m.calls, m.items)
+	}
+	want := map[ErrorCode]int{"": 3, CodeChecksum: 1, CodeLength: 2, CodeAllSame: 1}
+	for code, n := range want {
+		if m.outcomes[code] != n {
+			t.Errorf("outcome %q counted %d times, want %d", code, m.outcomes[code], n)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	v.ValidateBatch(ctx, []BatchItem{{TIN: "3036045681"}, {TIN: "12"}})
+	v.ValidateContext(ctx, "3036045681", nil)
+	if len(m.outcomes) != len(want) || m.outcomes[CodeUnknown] != 0 {
+		t.Errorf("cancelled calls must not be counted: %v", m.outcomes)
+	}
+}
+
+func TestValidator_Compose(t *testing.T) {
+	m := &recordingMetrics{outcomes: make(map[ErrorCode]int)}
+	var v Validator = NewClient()
+	v = Measured(Logged(Cached(v, 100, 0), slog.New(slog.DiscardHandler)), m)
+
+	res, err := v.Validate("3036045681", nil)
+	if err != nil || !res.Valid || m.outcomes[""] != 1 {
+		t.Fatalf("composed validator: %+v, %v", res, err)
+	}
+}